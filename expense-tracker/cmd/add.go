@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"expense-tracker/expense"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 // addCmd represents the add command
@@ -16,26 +17,89 @@ var addCmd = &cobra.Command{
 	Short: "Add a new expense",
 	Long: `Add a new expense. For example:
 
-./expense-tracker add --description "Buy groceries" --amount 50.00`,
+./expense-tracker add --description "Buy groceries" --amount 50.00
+./expense-tracker add --description "Team lunch" --amount 42.50 --category food
+./expense-tracker add --description "Taxi in Paris" --amount 30 --currency EUR
+
+Without --currency the expense is in the configured base currency.
+
+Shared expenses record who paid and how the cost is split:
+
+./expense-tracker add -d "Team lunch" -a 90 --paid-by alice --with alice,bob,carol
+./expense-tracker add -d "Offsite hotel" -a 600 --paid-by bob --split shares --with alice=2,bob=1,carol=1
+./expense-tracker add -d "Taxi" -a 42.50 --paid-by carol --split exact --with alice=30,carol=12.50
+
+Notes and a receipt can be kept with the expense; the receipt is copied
+into the receipts directory:
+
+./expense-tracker add -d "Conference ticket" -a 399 --notes "Reimbursable, GopherCon" --receipt ~/Downloads/invoice.pdf
+
+If the category has a budget for the current month and this expense takes
+spending over it, a warning is printed.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Description and amount are required flags, subcommands are written as a function and flags are accessed using cmd.Flags().Get<Type>()
 		// If the flags are not provided, Cobra will automatically handle the error and display usage information.
 		description, _ := cmd.Flags().GetString("description")
 		amount, _ := cmd.Flags().GetFloat64("amount")
+		category, _ := cmd.Flags().GetString("category")
+		currency, _ := cmd.Flags().GetString("currency")
+		paidBy, _ := cmd.Flags().GetString("paid-by")
+		split, _ := cmd.Flags().GetString("split")
+		with, _ := cmd.Flags().GetString("with")
+		notes, _ := cmd.Flags().GetString("notes")
+		receipt, _ := cmd.Flags().GetString("receipt")
 		if description == "" || amount <= 0 {
 			fmt.Println("Error: Description and amount are required.")
 			return
 		}
-		err,ID := expense.AddExpense(description, amount)
+		if (paidBy == "") != (with == "") {
+			fmt.Println("Error: Shared expenses need both --paid-by and --with.")
+			return
+		}
+		// Check the split and receipt before anything is saved
+		if with != "" {
+			if _, err := expense.ParseSplits(split, with, amount); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+		if receipt != "" {
+			if err := expense.CheckReceipt(receipt); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+		err,ID := expense.AddExpense(description, amount, category, currency)
 		if err != nil {
 			fmt.Printf("Error adding expense: %v\n", err)
 			return
 		}
 		fmt.Println("Expense added successfully! ID:", ID)
-		// You can also print the expense details if needed
-		// fmt.Printf("Description: %s, Amount: %.2f\n", description, amount)
-		// For now, just print a message
-		fmt.Println("add called")
+		if with != "" {
+			err = expense.ShareExpense(ID, paidBy, split, with)
+			if err != nil {
+				fmt.Printf("Error sharing expense: %v\n", err)
+				return
+			}
+		}
+		if notes != "" {
+			err = expense.SetNotes(ID, notes)
+			if err != nil {
+				fmt.Printf("Error saving notes: %v\n", err)
+				return
+			}
+		}
+		if receipt != "" {
+			stored, err := expense.AttachReceipt(ID, receipt)
+			if err != nil {
+				fmt.Printf("Error attaching receipt: %v\n", err)
+				return
+			}
+			fmt.Println("Receipt stored at", stored)
+		}
+		if category != "" {
+			warnOverBudget(category, time.Now().Format(expense.MonthLayout))
+		}
 	},
 }
 
@@ -45,6 +109,13 @@ func init() {
 	// Add flags to addCmd
 	addCmd.Flags().StringP("description", "d", "", "Description of the expense (required)")
 	addCmd.Flags().Float64P("amount", "a", 0, "Amount of the expense (required)")
+	addCmd.Flags().StringP("category", "c", "", "Category of the expense, e.g. food")
+	addCmd.Flags().String("currency", "", "Currency of the amount, e.g. EUR (default the base currency)")
+	addCmd.Flags().StringP("notes", "N", "", "Longer notes about the expense")
+	addCmd.Flags().StringP("receipt", "r", "", "File to attach as the receipt, e.g. a PDF or photo")
+	addCmd.Flags().String("paid-by", "", "Who paid, for a shared expense")
+	addCmd.Flags().String("split", expense.SplitEqual, "How to split a shared expense: equal, shares or exact")
+	addCmd.Flags().String("with", "", "Participants: alice,bob for equal; alice=2,bob=1 for shares; alice=30,bob=12.50 for exact")
 
 	//Mark flags as required
 	addCmd.MarkFlagRequired("description")
@@ -59,3 +130,17 @@ func init() {
 	// is called directly, e.g.:
 	// addCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
+
+// warnOverBudget prints a warning when spending in category during month
+// has gone past its budget
+func warnOverBudget(category, month string) {
+	status, err := expense.CategoryStatus(category, month)
+	if err != nil {
+		fmt.Printf("Error checking budget: %v\n", err)
+		return
+	}
+	if status.Over() {
+		fmt.Printf("Warning: %s spending for %s is %.2f, over the %.2f budget by %.2f\n",
+			status.Category, month, status.Spent, status.Budget, status.Spent-status.Budget)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+)
+
+// balancesCmd represents the balances command
+var balancesCmd = &cobra.Command{
+	Use:   "balances",
+	Short: "Show who owes whom for shared expenses",
+	Long: `Show what each person is owed or owes across all shared expenses and
+settlements, and the fewest payments that would settle everyone up.
+Amounts are converted into the base currency (or --currency) at each
+expense's own rate. For example:
+
+./expense-tracker balances`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_, currency, err := currencyFlag(cmd)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		balances, transfers, currency, ok := loadBalances(currency)
+		if !ok {
+			return
+		}
+		fmt.Println("Person\t\tBalance")
+		for _, b := range balances {
+			state := "is owed"
+			if b.Amount < 0 {
+				state = "owes"
+			}
+			fmt.Printf("%s\t\t%+.2f %s\t(%s %.2f)\n", b.Person, b.Amount, currency, state, abs(b.Amount))
+		}
+		fmt.Println()
+		fmt.Println("To settle up:")
+		printTransfers(transfers, currency)
+	},
+}
+
+// loadBalances computes balances and the transfers that settle them,
+// printing a message and returning false when there is nothing to show
+func loadBalances(currency string) ([]expense.Balance, []expense.Transfer, string, bool) {
+	converter, err := expense.NewConverter()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		return nil, nil, "", false
+	}
+	if currency == "" {
+		currency = converter.Base
+	}
+	balances, err := expense.Balances(currency)
+	if err != nil {
+		fmt.Printf("Error computing balances: %v\n", err)
+		return nil, nil, "", false
+	}
+	if len(balances) == 0 {
+		fmt.Println("Everyone is settled up.")
+		return nil, nil, "", false
+	}
+	return balances, expense.SettleUp(balances), currency, true
+}
+
+func printTransfers(transfers []expense.Transfer, currency string) {
+	for _, t := range transfers {
+		fmt.Printf("  %s pays %s %.2f %s\n", t.From, t.To, t.Amount, currency)
+	}
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+func init() {
+	rootCmd.AddCommand(balancesCmd)
+	balancesCmd.Flags().String("currency", "", `Currency to show balances in (default the base currency; "base" also works)`)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+// budgetCmd represents the budget command
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Manage monthly budgets per category",
+	Long: `Manage monthly budgets per category. For example:
+
+./expense-tracker budget set --category food --month 2026-11 --amount 400
+./expense-tracker budget list`,
+}
+
+// budgetSetCmd represents the budget set command
+var budgetSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the budget for a category in a month",
+	Long: `Set the budget for a category in a month. Setting it again replaces the
+previous amount. The month defaults to the current one. For example:
+
+./expense-tracker budget set --category food --month 2026-11 --amount 400`,
+	Run: func(cmd *cobra.Command, args []string) {
+		category, _ := cmd.Flags().GetString("category")
+		month, _ := cmd.Flags().GetString("month")
+		amount, _ := cmd.Flags().GetFloat64("amount")
+		if category == "" || amount <= 0 {
+			fmt.Println("Error: Category and amount are required.")
+			return
+		}
+		if month == "" {
+			month = time.Now().Format(expense.MonthLayout)
+		}
+		err := expense.SetBudget(category, month, amount)
+		if err != nil {
+			fmt.Printf("Error setting budget: %v\n", err)
+			return
+		}
+		fmt.Printf("Budget for %s in %s set to %.2f\n", expense.NormalizeCategory(category), month, amount)
+		warnOverBudget(category, month)
+	},
+}
+
+// budgetListCmd represents the budget list command
+var budgetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all budgets",
+	Long: `List all budgets, optionally for a single month. For example:
+
+./expense-tracker budget list
+./expense-tracker budget list --month 2026-11`,
+	Run: func(cmd *cobra.Command, args []string) {
+		month, _ := cmd.Flags().GetString("month")
+		budgets, err := expense.LoadBudgets()
+		if err != nil {
+			fmt.Printf("Error loading budgets: %v\n", err)
+			return
+		}
+		found := false
+		for _, b := range budgets {
+			if month != "" && b.Month != month {
+				continue
+			}
+			if !found {
+				fmt.Println("Month\t\tCategory\tAmount")
+				found = true
+			}
+			fmt.Printf("%s\t\t%s\t\t%.2f\n", b.Month, b.Category, b.Amount)
+		}
+		if !found {
+			fmt.Println("No budgets found.")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(budgetCmd)
+	budgetCmd.AddCommand(budgetSetCmd)
+	budgetCmd.AddCommand(budgetListCmd)
+
+	budgetSetCmd.Flags().StringP("category", "c", "", "Category the budget applies to (required)")
+	budgetSetCmd.Flags().StringP("month", "m", "", "Month the budget applies to (YYYY-MM, defaults to the current month)")
+	budgetSetCmd.Flags().Float64P("amount", "a", 0, "Budget amount (required)")
+	budgetListCmd.Flags().StringP("month", "m", "", "Only list budgets for this month (YYYY-MM)")
+}
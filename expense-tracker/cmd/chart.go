@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Block characters used to draw charts in the terminal
+var (
+	barEighths  = []rune(" ▏▎▍▌▋▊▉")
+	sparkLevels = []rune("▁▂▃▄▅▆▇█")
+)
+
+// bar draws value as a horizontal bar, with max filling width cells
+func bar(value, max float64, width int) string {
+	if max <= 0 || value <= 0 {
+		return ""
+	}
+	eighths := int(math.Round(value / max * float64(width) * 8))
+	if eighths == 0 {
+		eighths = 1 // keep small values visible
+	}
+	full := strings.Repeat("█", eighths/8)
+	if rest := eighths % 8; rest > 0 {
+		full += string(barEighths[rest])
+	}
+	return full
+}
+
+// sparkline draws values as a one-line chart scaled between their minimum
+// and maximum
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	min, max := values[0], values[0]
+	for _, v := range values {
+		min, max = math.Min(min, v), math.Max(max, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if max > min {
+			level = int((v - min) / (max - min) * float64(len(sparkLevels)-1))
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+// trend formats a relative change as a percentage with an arrow
+func trend(change float64) string {
+	switch {
+	case change > 0:
+		return fmt.Sprintf("+%.1f%% ▲", change*100)
+	case change < 0:
+		return fmt.Sprintf("%.1f%% ▼", change*100)
+	}
+	return "0.0% ="
+}
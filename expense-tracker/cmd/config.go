@@ -0,0 +1,52 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show or change settings",
+	Long: `Show or change settings. Without flags the current settings are shown.
+For example:
+
+./expense-tracker config
+./expense-tracker config --base-currency EUR
+
+The base currency is what expenses without --currency are recorded in,
+what budgets are set in, and what summaries report in by default.
+Changing it does not change expenses that were already recorded.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := expense.LoadConfig()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			return
+		}
+		base, _ := cmd.Flags().GetString("base-currency")
+		if base != "" {
+			config.BaseCurrency, err = expense.NormalizeCurrency(base)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			err = expense.SaveConfig(config)
+			if err != nil {
+				fmt.Printf("Error saving config: %v\n", err)
+				return
+			}
+		}
+		fmt.Println("Base currency:", config.BaseCurrency)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.Flags().String("base-currency", "", "Set the base currency, e.g. EUR")
+}
@@ -17,7 +17,9 @@ var deleteCmd = &cobra.Command{
 	Long: `Delete an expense by ID
 	For example:
 
-./expense-tracker delete --id 1`,
+./expense-tracker delete --id 1
+
+An attached receipt is deleted along with the expense.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		id, err := cmd.Flags().GetInt("id")
 		if err != nil {
@@ -0,0 +1,105 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export expenses as CSV or JSON",
+	Long: `Export expenses as CSV or JSON, optionally for a date range and category.
+Output goes to stdout unless --output is given. For example:
+
+./expense-tracker export --format csv --output expenses.csv
+./expense-tracker export --format json --from 2026-11-01 --to 2026-11-30 --category food`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		category, _ := cmd.Flags().GetString("category")
+
+		filter := expense.Filter{Category: category}
+		var err error
+		if from != "" {
+			filter.From, err = expense.ParseDay(from)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+		if to != "" {
+			filter.To, err = expense.ParseDay(to)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+		var write func(io.Writer, []expense.Expense) error
+		switch format {
+		case "csv":
+			write = expense.ExportCSV
+		case "json":
+			write = expense.ExportJSON
+		default:
+			fmt.Println("Error: Format must be csv or json.")
+			return
+		}
+
+		expenses, err := expense.LoadExpenses()
+		if err != nil {
+			fmt.Printf("Error loading expenses: %v\n", err)
+			return
+		}
+		expenses = expense.FilterExpenses(expenses, filter)
+		converter, err := expense.NewConverter()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			return
+		}
+		for i := range expenses {
+			expenses[i].Currency = converter.CurrencyOf(expenses[i])
+		}
+
+		if output == "" {
+			err = write(os.Stdout, expenses)
+			if err != nil {
+				fmt.Printf("Error exporting expenses: %v\n", err)
+			}
+			return
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			fmt.Printf("Error creating file: %v\n", err)
+			return
+		}
+		err = write(f, expenses)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Printf("Error exporting expenses: %v\n", err)
+			return
+		}
+		fmt.Printf("Exported %d expenses to %s\n", len(expenses), output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringP("format", "f", "csv", "Export format: csv or json")
+	exportCmd.Flags().StringP("output", "o", "", "File to write (default stdout)")
+	exportCmd.Flags().String("from", "", "Only export expenses on or after this date (YYYY-MM-DD)")
+	exportCmd.Flags().String("to", "", "Only export expenses on or before this date (YYYY-MM-DD)")
+	exportCmd.Flags().StringP("category", "c", "", "Only export expenses in this category")
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import expenses from a bank statement (CSV or OFX)",
+	Long: `Import expenses from a bank statement in CSV or OFX format. The format is
+taken from the file extension unless --format is given.
+
+For CSV, map the statement's columns with header names, or with 1-based
+column numbers when the file has no header row:
+
+./expense-tracker import --file statement.csv --date-col "Posting Date" --description-col Payee --amount-col Amount --date-format DD/MM/YYYY
+./expense-tracker import --file export.csv --no-header --date-col 1 --description-col 3 --amount-col 4 --negative
+./expense-tracker import --file statement.ofx --category groceries
+
+Rows that match an existing expense on date, amount and description are
+reported as duplicates and not imported. Use --dry-run to preview.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+		category, _ := cmd.Flags().GetString("category")
+		currency, _ := cmd.Flags().GetString("currency")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if file == "" {
+			fmt.Println("Error: File is required.")
+			return
+		}
+		if format == "" {
+			switch strings.ToLower(filepath.Ext(file)) {
+			case ".csv":
+				format = "csv"
+			case ".ofx", ".qfx":
+				format = "ofx"
+			}
+		}
+
+		mapping := expense.CSVMapping{}
+		mapping.Date, _ = cmd.Flags().GetString("date-col")
+		mapping.Description, _ = cmd.Flags().GetString("description-col")
+		mapping.Amount, _ = cmd.Flags().GetString("amount-col")
+		mapping.Category, _ = cmd.Flags().GetString("category-col")
+		mapping.DateFormat, _ = cmd.Flags().GetString("date-format")
+		mapping.NoHeader, _ = cmd.Flags().GetBool("no-header")
+		mapping.NegativeIsExpense, _ = cmd.Flags().GetBool("negative")
+		delimiter, _ := cmd.Flags().GetString("delimiter")
+		if delimiter == `\t` {
+			delimiter = "\t"
+		}
+		if utf8.RuneCountInString(delimiter) != 1 {
+			fmt.Println("Error: Delimiter must be a single character.")
+			return
+		}
+		mapping.Delimiter, _ = utf8.DecodeRuneInString(delimiter)
+
+		f, err := os.Open(file)
+		if err != nil {
+			fmt.Printf("Error opening file: %v\n", err)
+			return
+		}
+		defer f.Close()
+		records, skipped, err := expense.ParseStatement(f, format, mapping)
+		if err != nil {
+			fmt.Printf("Error importing expenses: %v\n", err)
+			return
+		}
+		for i := range records {
+			if records[i].Category == "" {
+				records[i].Category = expense.NormalizeCategory(category)
+			}
+		}
+
+		result, err := expense.ImportExpenses(records, currency, dryRun)
+		if err != nil {
+			fmt.Printf("Error importing expenses: %v\n", err)
+			return
+		}
+		for _, e := range result.Duplicates {
+			fmt.Printf("Duplicate skipped: %s\t%s\t%.2f\n", e.Date.Format("2006-01-02"), e.Description, e.Amount)
+		}
+		verb := "Imported"
+		if dryRun {
+			verb = "Would import"
+		}
+		fmt.Printf("%s %d expenses (%d duplicates, %d non-expense rows skipped).\n", verb, len(result.Added), len(result.Duplicates), skipped)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringP("file", "f", "", "Statement file to import (required)")
+	importCmd.Flags().String("format", "", "Statement format: csv or ofx (default from file extension)")
+	importCmd.Flags().StringP("category", "c", "", "Category for imported expenses that have none")
+	importCmd.Flags().String("currency", "", "Currency of the statement when the file does not say (default the base currency)")
+	importCmd.Flags().Bool("dry-run", false, "Show what would be imported without saving")
+	importCmd.Flags().String("date-col", "date", "CSV column holding the date (header name or number)")
+	importCmd.Flags().String("description-col", "description", "CSV column holding the description (header name or number)")
+	importCmd.Flags().String("amount-col", "amount", "CSV column holding the amount (header name or number)")
+	importCmd.Flags().String("category-col", "", "CSV column holding the category (header name or number)")
+	importCmd.Flags().String("date-format", expense.DefaultDateFormat, "CSV date format, e.g. DD/MM/YYYY or MM/DD/YYYY")
+	importCmd.Flags().String("delimiter", ",", `CSV field delimiter (use \t for tab)`)
+	importCmd.Flags().Bool("no-header", false, "CSV file has no header row; columns must be numbers")
+	importCmd.Flags().Bool("negative", false, "CSV amounts are negative for money spent; positive rows are skipped")
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"expense-tracker/expense"
 	"github.com/spf13/cobra"
+	"strings"
 )
 
 // listCmd represents the list command
@@ -16,27 +17,94 @@ var listCmd = &cobra.Command{
 	Short: "List all expenses",
 	Long: `List of all expenses. For example:
 
-./expense-tracker list`,
+./expense-tracker list
+./expense-tracker list --currency base
+./expense-tracker list --currency EUR
+
+With --currency an extra column shows each amount converted at the
+exchange rate of the expense's date ("base" means the base currency).`,
 	Run: func(cmd *cobra.Command, args []string) {
-		expense, err := expense.LoadExpenses()
+		expenses, err := expense.LoadExpenses()
 		if err != nil {
 			fmt.Printf("Error loading expenses: %v\n", err)
 			return
 		}
-		if len(expense) == 0 {
+		if len(expenses) == 0 {
 			fmt.Println("No expenses found.")
 			return
 		}
+		converter, currency, err := currencyFlag(cmd)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
 		fmt.Println("Expenses:")
-		fmt.Println("ID\tDescription\tAmount\t\tDate")
-		for _,e := range expense {
-			fmt.Printf("%d\t%s\t\t%.2f\t\t%s\n", e.ID, e.Description, e.Amount, e.Date.Format("2006-01-02 15:04:05"))
+		if currency == "" {
+			fmt.Println("ID\tDescription\tAmount\t\tCategory\tDate")
+		} else {
+			fmt.Printf("ID\tDescription\tAmount\t\tIn %s\t\tCategory\tDate\n", currency)
+		}
+		var convertErr error
+		for _,e := range expenses {
+			amount := fmt.Sprintf("%.2f %s", e.Amount, converter.CurrencyOf(e))
+			if currency != "" {
+				converted, err := converter.Convert(e, currency)
+				if err != nil {
+					convertErr = err
+					amount += "\t-"
+				} else {
+					amount += fmt.Sprintf("\t%.2f", converted)
+				}
+			}
+			fmt.Printf("%d\t%s\t\t%s\t\t%s\t%s%s\n", e.ID, e.Description, amount, expense.CategoryLabel(e.Category), e.Date.Format("2006-01-02 15:04:05"), sharing(e))
+		}
+		if convertErr != nil {
+			fmt.Printf("Some amounts could not be converted: %v\n", convertErr)
 		}
 	},
 }
 
+// sharing describes who paid a shared expense and who it is split between,
+// and where its receipt is kept
+func sharing(e expense.Expense) string {
+	receipt := ""
+	if e.Receipt != "" {
+		receipt = "\treceipt: " + e.Receipt
+	}
+	if e.PaidBy == "" || len(e.Splits) == 0 {
+		return receipt
+	}
+	parts := make([]string, len(e.Splits))
+	for i, s := range e.Splits {
+		parts[i] = fmt.Sprintf("%s %.2f", s.Person, s.Amount)
+	}
+	if e.Settlement {
+		return fmt.Sprintf("\tsettlement to %s", e.Splits[0].Person) + receipt
+	}
+	return fmt.Sprintf("\tpaid by %s, split %s: %s", e.PaidBy, e.SplitMode, strings.Join(parts, ", ")) + receipt
+}
+
+// currencyFlag reads the --currency flag, resolving "base" to the
+// configured base currency. An empty result means no conversion.
+func currencyFlag(cmd *cobra.Command) (*expense.Converter, string, error) {
+	converter, err := expense.NewConverter()
+	if err != nil {
+		return nil, "", err
+	}
+	currency, _ := cmd.Flags().GetString("currency")
+	if currency == "" {
+		return converter, "", nil
+	}
+	if currency == "base" {
+		return converter, converter.Base, nil
+	}
+	currency, err = expense.NormalizeCurrency(currency)
+	return converter, currency, err
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().String("currency", "", `Also show amounts converted into this currency ("base" for the base currency)`)
 
 	// Here you will define your flags and configuration settings.
 
@@ -0,0 +1,163 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ratesCmd represents the rates command
+var ratesCmd = &cobra.Command{
+	Use:   "rates",
+	Short: "Manage the local exchange rate table",
+	Long: `Manage the local exchange rate table used to convert between currencies.
+Rates are kept in rates.json and are never fetched over the network.
+
+./expense-tracker rates import --file ecb.json
+./expense-tracker rates list`,
+}
+
+// ratesImportCmd represents the rates import command
+var ratesImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import exchange rates from a CSV or JSON file",
+	Long: `Import exchange rates from a file, adding to or replacing rates already
+held for the same days.
+
+JSON files use the same shape as rates.json, which is also what ECB-style
+rate services publish:
+
+  {"base": "EUR", "rates": {"2026-11-02": {"USD": 1.08, "INR": 90.4}}}
+
+CSV files have date, currency and rate columns, with rates per unit of
+--base (default the base currency):
+
+  date,currency,rate
+  2026-11-02,USD,1.08
+
+For example:
+
+./expense-tracker rates import --file rates.csv --base EUR`,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+		base, _ := cmd.Flags().GetString("base")
+		if file == "" {
+			fmt.Println("Error: File is required.")
+			return
+		}
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+		}
+		if base == "" && format == "csv" {
+			config, err := expense.LoadConfig()
+			if err != nil {
+				fmt.Printf("Error loading config: %v\n", err)
+				return
+			}
+			base = config.BaseCurrency
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			fmt.Printf("Error opening file: %v\n", err)
+			return
+		}
+		defer f.Close()
+		imported, err := expense.ParseRates(f, format, base)
+		if err != nil {
+			fmt.Printf("Error importing rates: %v\n", err)
+			return
+		}
+		rates, err := expense.LoadRates()
+		if err != nil {
+			fmt.Printf("Error loading rates: %v\n", err)
+			return
+		}
+		count, err := rates.Merge(imported)
+		if err != nil {
+			fmt.Printf("Error importing rates: %v\n", err)
+			return
+		}
+		err = expense.SaveRates(rates)
+		if err != nil {
+			fmt.Printf("Error saving rates: %v\n", err)
+			return
+		}
+		fmt.Printf("Imported %d rates for %d days (quoted per 1 %s).\n", count, len(imported.Rates), rates.Base)
+	},
+}
+
+// ratesListCmd represents the rates list command
+var ratesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List exchange rates",
+	Long: `List the exchange rates held for a day, using the latest rate on or before
+it for each currency. Defaults to the most recent day. For example:
+
+./expense-tracker rates list
+./expense-tracker rates list --date 2026-11-02`,
+	Run: func(cmd *cobra.Command, args []string) {
+		date, _ := cmd.Flags().GetString("date")
+		rates, err := expense.LoadRates()
+		if err != nil {
+			fmt.Printf("Error loading rates: %v\n", err)
+			return
+		}
+		days := rates.Days()
+		if len(days) == 0 {
+			fmt.Println("No exchange rates found.")
+			return
+		}
+		if date == "" {
+			date = days[len(days)-1]
+		}
+		day, err := expense.ParseDay(date)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		currencies := map[string]bool{}
+		for _, quotes := range rates.Rates {
+			for currency := range quotes {
+				currencies[currency] = true
+			}
+		}
+		codes := make([]string, 0, len(currencies))
+		for currency := range currencies {
+			codes = append(codes, currency)
+		}
+		sort.Strings(codes)
+
+		fmt.Printf("Rates on %s per 1 %s (%d days held, %s to %s):\n", date, rates.Base, len(days), days[0], days[len(days)-1])
+		fmt.Println("Currency\tRate")
+		for _, currency := range codes {
+			rate, err := rates.Rate(currency, day)
+			if err != nil {
+				fmt.Printf("%s\t\t-\n", currency)
+				continue
+			}
+			fmt.Printf("%s\t\t%.4f\n", currency, rate)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ratesCmd)
+	ratesCmd.AddCommand(ratesImportCmd)
+	ratesCmd.AddCommand(ratesListCmd)
+
+	ratesImportCmd.Flags().StringP("file", "f", "", "Rates file to import (required)")
+	ratesImportCmd.Flags().String("format", "", "Rates file format: csv or json (default from file extension)")
+	ratesImportCmd.Flags().String("base", "", "Currency the rates are quoted against (CSV default the base currency; overrides the JSON base)")
+	ratesListCmd.Flags().String("date", "", "Day to list rates for (YYYY-MM-DD)")
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+// recurringCmd represents the recurring command
+var recurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: "Manage recurring expenses such as rent and subscriptions",
+	Long: `Manage recurring expenses such as rent and subscriptions. Occurrences are
+added to the ledger automatically once they are due, whenever any command
+runs (or explicitly with sync). For example:
+
+./expense-tracker recurring add --description Rent --amount 1200 --interval monthly --start 2026-01-01 --category rent
+./expense-tracker recurring add -d "Music streaming" -a 9.99 --interval monthly --start 2026-03-15 --end 2027-03-14
+./expense-tracker recurring list
+./expense-tracker recurring delete --id 2`,
+}
+
+// recurringAddCmd represents the recurring add command
+var recurringAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a recurring expense",
+	Long: `Add a recurring expense. The interval is daily, weekly, biweekly, monthly,
+quarterly, yearly or "N days|weeks|months|years". The start date is the
+first occurrence and defaults to today; occurrences between the start and
+today are added straight away.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		r := expense.Recurring{}
+		r.Description, _ = cmd.Flags().GetString("description")
+		r.Amount, _ = cmd.Flags().GetFloat64("amount")
+		r.Category, _ = cmd.Flags().GetString("category")
+		r.Currency, _ = cmd.Flags().GetString("currency")
+		r.Interval, _ = cmd.Flags().GetString("interval")
+		r.Start, _ = cmd.Flags().GetString("start")
+		r.End, _ = cmd.Flags().GetString("end")
+		if r.Start == "" {
+			r.Start = time.Now().Format("2006-01-02")
+		}
+		r, err := expense.AddRecurring(r)
+		if err != nil {
+			fmt.Printf("Error adding recurring expense: %v\n", err)
+			return
+		}
+		fmt.Println("Recurring expense added successfully! ID:", r.ID)
+		syncRecurring()
+	},
+}
+
+// recurringListCmd represents the recurring list command
+var recurringListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring expenses",
+	Run: func(cmd *cobra.Command, args []string) {
+		recurring, err := expense.LoadRecurring()
+		if err != nil {
+			fmt.Printf("Error loading recurring expenses: %v\n", err)
+			return
+		}
+		if len(recurring) == 0 {
+			fmt.Println("No recurring expenses found.")
+			return
+		}
+		fmt.Println("ID\tDescription\tAmount\t\tInterval\tCategory\tStart\t\tEnd\t\tNext")
+		for _, r := range recurring {
+			end := r.End
+			if end == "" {
+				end = "-\t"
+			}
+			next := "ended"
+			if date, ok, err := r.Next(); err != nil {
+				next = "-"
+			} else if ok {
+				next = date.Format("2006-01-02")
+			}
+			fmt.Printf("%d\t%s\t\t%.2f %s\t%s\t\t%s\t%s\t%s\t%s\n", r.ID, r.Description, r.Amount, r.Currency, r.Interval, expense.CategoryLabel(r.Category), r.Start, end, next)
+		}
+	},
+}
+
+// recurringDeleteCmd represents the recurring delete command
+var recurringDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a recurring expense",
+	Long: `Delete a recurring expense so no further occurrences are added. Expenses
+it already added stay in the ledger.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		id, _ := cmd.Flags().GetInt("id")
+		if id <= 0 {
+			fmt.Println("Error: ID must be a positive integer.")
+			return
+		}
+		r, err := expense.DeleteRecurring(id)
+		if err != nil {
+			fmt.Printf("Error deleting recurring expense with ID %d: %v\n", id, err)
+			return
+		}
+		fmt.Printf("Deleted Recurring Expense: ID=%d, Description=%s, Amount=%.2f, Interval=%s\n", r.ID, r.Description, r.Amount, r.Interval)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recurringCmd)
+	recurringCmd.AddCommand(recurringAddCmd)
+	recurringCmd.AddCommand(recurringListCmd)
+	recurringCmd.AddCommand(recurringDeleteCmd)
+
+	recurringAddCmd.Flags().StringP("description", "d", "", "Description of the expense (required)")
+	recurringAddCmd.Flags().Float64P("amount", "a", 0, "Amount of each occurrence (required)")
+	recurringAddCmd.Flags().StringP("category", "c", "", "Category of the expense")
+	recurringAddCmd.Flags().String("currency", "", "Currency of the amount (default the base currency)")
+	recurringAddCmd.Flags().StringP("interval", "n", "monthly", `How often it repeats: daily, weekly, biweekly, monthly, quarterly, yearly or "N days|weeks|months|years"`)
+	recurringAddCmd.Flags().String("start", "", "Date of the first occurrence (YYYY-MM-DD, default today)")
+	recurringAddCmd.Flags().String("end", "", "Last date an occurrence may fall on (YYYY-MM-DD, optional)")
+	recurringDeleteCmd.Flags().IntP("id", "i", 0, "ID of the recurring expense to delete (required)")
+	recurringDeleteCmd.MarkFlagRequired("id")
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+	"strings"
+	"time"
+)
+
+// barWidth is the width in cells of the longest bar in a report
+const barWidth = 40
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report spending over time or by category with charts",
+	Long: `Report spending for a period, grouped by day, week, month or category,
+with bar charts, a sparkline and a comparison against the previous period
+of the same length. For example:
+
+./expense-tracker report month --year 2026
+./expense-tracker report week --year 2026 --quarter 3
+./expense-tracker report day --from 2026-11-01 --to 2026-11-15
+./expense-tracker report category --year 2026 --month 11 --currency EUR
+
+Without a period the current month is reported. --month and --quarter
+without --year mean the current year.`,
+}
+
+// newReportCmd creates the report subcommand for one grouping
+func newReportCmd(by string, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   by,
+		Short: short,
+		Run: func(cmd *cobra.Command, args []string) {
+			runReport(cmd, by)
+		},
+	}
+}
+
+func runReport(cmd *cobra.Command, by string) {
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	year, _ := cmd.Flags().GetInt("year")
+	month, _ := cmd.Flags().GetInt("month")
+	quarter, _ := cmd.Flags().GetInt("quarter")
+	_, currency, err := currencyFlag(cmd)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	period, err := expense.ResolvePeriod(from, to, year, month, quarter)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if period.From.IsZero() && period.To.IsZero() {
+		now := time.Now()
+		period = expense.MonthPeriod(now.Year(), now.Month())
+	}
+
+	report, err := expense.BuildReport(period, by, currency)
+	if err != nil {
+		fmt.Printf("Error generating report: %v\n", err)
+		return
+	}
+	fmt.Printf("Spending by %s, %s (%s)\n\n", by, report.Period, report.Currency)
+	if len(report.Buckets) == 0 {
+		fmt.Println("No expenses found.")
+		return
+	}
+
+	width, max := 0, 0.0
+	values := make([]float64, 0, len(report.Buckets))
+	for _, b := range report.Buckets {
+		width = maxInt(width, len([]rune(b.Label)))
+		if b.Total > max {
+			max = b.Total
+		}
+		values = append(values, b.Total)
+	}
+	for _, b := range report.Buckets {
+		line := fmt.Sprintf("%-*s  %10.2f  %-*s", width, b.Label, b.Total, barWidth, bar(b.Total, max, barWidth))
+		if by == expense.ByCategory && report.HasPrevious {
+			if b.Previous == 0 {
+				line += "  new"
+			} else {
+				line += "  " + trend((b.Total-b.Previous)/b.Previous)
+			}
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	fmt.Println()
+	if by != expense.ByCategory && len(values) > 1 {
+		fmt.Printf("Trend:    %s\n", sparkline(values))
+	}
+	fmt.Printf("Total:    %.2f %s across %d expenses\n", report.Total, report.Currency, report.Count)
+	if report.HasPrevious {
+		line := fmt.Sprintf("Previous: %.2f %s (%s)", report.PreviousTotal, report.Currency, report.Previous)
+		if change, ok := report.Change(); ok {
+			line += ", " + trend(change)
+		}
+		fmt.Println(line)
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(newReportCmd(expense.ByDay, "Report spending per day"))
+	reportCmd.AddCommand(newReportCmd(expense.ByWeek, "Report spending per ISO week"))
+	reportCmd.AddCommand(newReportCmd(expense.ByMonth, "Report spending per month"))
+	reportCmd.AddCommand(newReportCmd(expense.ByCategory, "Report spending per category"))
+
+	reportCmd.PersistentFlags().String("from", "", "First day of the period (YYYY-MM-DD)")
+	reportCmd.PersistentFlags().String("to", "", "Last day of the period (YYYY-MM-DD, default today)")
+	reportCmd.PersistentFlags().IntP("year", "y", 0, "Year to report on")
+	reportCmd.PersistentFlags().IntP("month", "m", 0, "Month of the year to report on (1-12)")
+	reportCmd.PersistentFlags().IntP("quarter", "q", 0, "Quarter of the year to report on (1-4)")
+	reportCmd.PersistentFlags().String("currency", "", `Currency to report in (default the base currency; "base" also works)`)
+}
@@ -25,6 +25,14 @@ to quickly create a Cobra application.`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+
+	// Recurring expenses that have come due are added before any command
+	// runs, so every command sees an up-to-date ledger
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if cmd != syncCmd {
+			syncRecurring()
+		}
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
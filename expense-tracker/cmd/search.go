@@ -0,0 +1,65 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search [terms and predicates...]",
+	Short: "Search expenses by text, amount and date",
+	Long: `Search the description, notes and category of every expense. All words
+must match (quote a phrase to search for it as a whole); results are
+ordered best match first. Amount and date predicates narrow the search:
+
+  amount>50  amount<=100  amount=12.50  amount!=0
+  date>=2026-10-01  date<2026-10  date=2026
+
+A date can be a day, a month or a year and compares against the whole
+range, so date=2026-10 means any day in October. Amounts are compared in
+each expense's own currency. For example:
+
+./expense-tracker search lunch
+./expense-tracker search "team offsite" amount>100
+./expense-tracker search amount>=500 date=2026`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		query, err := expense.ParseSearch(args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		expenses, err := expense.SearchExpenses(query)
+		if err != nil {
+			fmt.Printf("Error searching expenses: %v\n", err)
+			return
+		}
+		if len(expenses) == 0 {
+			fmt.Println("No matching expenses found.")
+			return
+		}
+		converter, err := expense.NewConverter()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			return
+		}
+		fmt.Printf("Found %d expenses:\n", len(expenses))
+		fmt.Println("ID\tDescription\tAmount\t\tCategory\tDate")
+		for _, e := range expenses {
+			fmt.Printf("%d\t%s\t\t%.2f %s\t\t%s\t%s%s\n", e.ID, e.Description, e.Amount, converter.CurrencyOf(e), expense.CategoryLabel(e.Category), e.Date.Format("2006-01-02"), sharing(e))
+			if e.Notes != "" {
+				fmt.Printf("\tNotes: %s\n", e.Notes)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+}
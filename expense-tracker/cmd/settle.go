@@ -0,0 +1,49 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+)
+
+// settleCmd represents the settle command
+var settleCmd = &cobra.Command{
+	Use:   "settle",
+	Short: "Settle up shared expenses with the fewest payments",
+	Long: `Work out the fewest payments that bring everyone's balance to zero and
+record each one as a settlement entry in the base currency. Settlements
+change balances but are not counted as spending. For example:
+
+./expense-tracker settle --dry-run
+./expense-tracker settle`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		_, transfers, currency, ok := loadBalances("")
+		if !ok {
+			return
+		}
+		if dryRun {
+			fmt.Println("Payments needed to settle up:")
+			printTransfers(transfers, currency)
+			return
+		}
+		added, err := expense.RecordSettlements(transfers, currency)
+		if err != nil {
+			fmt.Printf("Error recording settlements: %v\n", err)
+			return
+		}
+		fmt.Println("Recorded settlements:")
+		for _, e := range added {
+			fmt.Printf("  ID %d: %s pays %s %.2f %s\n", e.ID, e.PaidBy, e.Splits[0].Person, e.Amount, e.Currency)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(settleCmd)
+	settleCmd.Flags().Bool("dry-run", false, "Show the payments without recording them")
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"expense-tracker/expense"
 	"github.com/spf13/cobra"
+	"strconv"
+	"time"
 )
 
 // summaryCmd represents the summary command
@@ -19,40 +21,88 @@ var summaryCmd = &cobra.Command{
 ./expense-tracker summary
  Summariza all expenses for a specific month.
  
- ./expense-tracker summary --month 7`,
+ ./expense-tracker summary --month 7
+ ./expense-tracker summary --month 7 --year 2025
+ ./expense-tracker summary --year 2025
+
+ A month number without --year means that month of the current year.
+
+ Summarize a specific month and compare each category with its budget.
+
+ ./expense-tracker summary --month 2026-11
+
+ Amounts in other currencies are converted at the rate of each expense's
+ date into the base currency, or into --currency.
+
+ ./expense-tracker summary --currency EUR`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("summary called")
-		month, _ := cmd.Flags().GetInt("month")
-		
-		if month == 0 {
-			expenses,err := expense.Summary()
-			if err != nil {
-				fmt.Printf("Error generating summary: %v\n", err)
+		month, _ := cmd.Flags().GetString("month")
+		year, _ := cmd.Flags().GetInt("year")
+		converter, currency, err := currencyFlag(cmd)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if currency == "" {
+			currency = converter.Base
+		}
+
+		period := expense.Period{}
+		if month != "" {
+			if _, err := time.Parse(expense.MonthLayout, month); err == nil {
+				period, _ = expense.ParseMonth(month)
+			} else {
+				m, err := strconv.Atoi(month)
+				if err != nil || m < 1 || m > 12 {
+				fmt.Println("Error: Month must be between 1 and 12 or in YYYY-MM format.")
 				return
+				}
+				// A bare month number means that month of --year, or of this year
+				period, _ = expense.ResolvePeriod("", "", year, m, 0)
 			}
-			fmt.Printf("Summary of Expenses: %.2f\n", expenses)
+		} else if year != 0 {
+			period = expense.YearPeriod(year)
+		}
+
+		expenses,err := expense.SummaryIn(currency, period)
+		if err != nil {
+			fmt.Printf("Error generating summary: %v\n", err)
+			return
+		}
+		if period.Bounded() {
+			fmt.Printf("Summary of Expenses for %s: %.2f %s\n", period, expenses, currency)
 		} else {
-			if month < 1 || month > 12 {
-			fmt.Println("Error: Month must be between 1 and 12.")
+			fmt.Printf("Summary of Expenses: %.2f %s\n", expenses, currency)
+		}
+
+		spending, err := expense.SpendingByCategory(period, currency)
+		if err != nil {
+			fmt.Printf("Error generating summary: %v\n", err)
 			return
+		}
+		if len(spending) == 0 {
+			return
+		}
+		fmt.Println("Category\tSpent\t\tBudget\t\tRemaining")
+		for _, s := range spending {
+			if !s.HasBudget {
+				fmt.Printf("%s\t%.2f\t\t-\t\t-\n", expense.CategoryLabel(s.Category), s.Spent)
+				continue
 			}
-			expenses,err := expense.Summary(month)
-			if err != nil {
-				fmt.Printf("Error generating summary: %v\n", err)
-				return
+			flag := ""
+			if s.Over() {
+				flag = "\tOVER BUDGET"
 			}
-			fmt.Printf("Summary of Expenses for month %d: %.2f\n", month, expenses)
+			fmt.Printf("%s\t%.2f\t\t%.2f\t\t%.2f%s\n", expense.CategoryLabel(s.Category), s.Spent, s.Budget, s.Budget-s.Spent, flag)
 		}
-		//if expenses == 0 {
-		//	fmt.Println("No expenses found.")
-		//	return
-		//}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(summaryCmd)
-	summaryCmd.Flags().IntP("month", "m", 0, "Month for which to summarize expenses (1-12). If not provided, summarizes all expenses.")
+	summaryCmd.Flags().String("currency", "", `Currency to report in (default the base currency; "base" also works)`)
+	summaryCmd.Flags().StringP("month", "m", "", "Month to summarize: 1-12 (of --year, default this year) or YYYY-MM. If not provided, summarizes all expenses.")
+	summaryCmd.Flags().IntP("year", "y", 0, "Year to summarize")
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
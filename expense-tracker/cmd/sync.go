@@ -0,0 +1,46 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Add recurring expenses that are due",
+	Long: `Add every occurrence of a recurring expense that is due by today and not
+yet in the ledger. This also happens automatically before any other
+command runs. For example:
+
+./expense-tracker sync`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if syncRecurring() == 0 {
+			fmt.Println("Recurring expenses are up to date.")
+		}
+	},
+}
+
+// syncRecurring adds due recurring expenses and reports them on stderr, so
+// output such as an export to stdout stays clean. It returns how many were
+// added.
+func syncRecurring() int {
+	added, err := expense.SyncRecurring()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error syncing recurring expenses: %v\n", err)
+	}
+	for _, e := range added {
+		fmt.Fprintf(os.Stderr, "Added recurring expense: ID=%d, Description=%s, Amount=%.2f %s, Date=%s\n", e.ID, e.Description, e.Amount, e.Currency, e.Date.Format("2006-01-02"))
+	}
+	return len(added)
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+}
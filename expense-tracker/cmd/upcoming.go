@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 NAME HERE [pranavppatil767@gmail.com]
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"expense-tracker/expense"
+	"github.com/spf13/cobra"
+)
+
+// upcomingCmd represents the upcoming command
+var upcomingCmd = &cobra.Command{
+	Use:   "upcoming",
+	Short: "List recurring charges expected soon",
+	Long: `List the recurring charges expected in the next N days and their projected
+total in the base currency (or --currency). For example:
+
+./expense-tracker upcoming
+./expense-tracker upcoming --days 90`,
+	Run: func(cmd *cobra.Command, args []string) {
+		days, _ := cmd.Flags().GetInt("days")
+		if days <= 0 {
+			fmt.Println("Error: Days must be a positive integer.")
+			return
+		}
+		converter, currency, err := currencyFlag(cmd)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if currency == "" {
+			currency = converter.Base
+		}
+		upcoming, err := expense.UpcomingCharges(days)
+		if err != nil {
+			fmt.Printf("Error listing upcoming charges: %v\n", err)
+			return
+		}
+		if len(upcoming) == 0 {
+			fmt.Printf("No recurring charges in the next %d days.\n", days)
+			return
+		}
+
+		total := 0.0
+		var convertErr error
+		fmt.Println("Date\t\tID\tDescription\tAmount\t\tCategory")
+		for _, u := range upcoming {
+			r := u.Recurring
+			fmt.Printf("%s\t%d\t%s\t\t%.2f %s\t%s\n", u.Date.Format("2006-01-02"), r.ID, r.Description, r.Amount, r.Currency, expense.CategoryLabel(r.Category))
+			amount, err := converter.ConvertAmount(r.Amount, r.Currency, currency, u.Date)
+			if err != nil {
+				convertErr = err
+				continue
+			}
+			total += amount
+		}
+		fmt.Printf("Projected total for the next %d days: %.2f %s\n", days, total, currency)
+		if convertErr != nil {
+			fmt.Printf("Some charges are left out of the total: %v\n", convertErr)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(upcomingCmd)
+	upcomingCmd.Flags().Int("days", 30, "How many days ahead to look")
+	upcomingCmd.Flags().String("currency", "", `Currency for the projected total (default the base currency; "base" also works)`)
+}
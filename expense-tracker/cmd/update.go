@@ -16,7 +16,15 @@ var updateCmd = &cobra.Command{
 	Short: "Update a specific expense by ID",
 	Long: `Update a specific expense by ID For example:
 
-./expense-tracker update --id 1 --description "Updated description" --amount 100.00`,
+./expense-tracker update --id 1 --description "Updated description" --amount 100.00
+./expense-tracker update --id 1 --category travel
+./expense-tracker update --id 1 --currency INR
+./expense-tracker update --id 1 --paid-by bob --split equal --with alice,bob
+
+./expense-tracker update --id 1 --notes "Approved by finance" --receipt scan.jpg
+
+Equal and shares splits follow a change of amount; exact splits must be
+given again. A new receipt replaces the old one.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		id, err := cmd.Flags().GetInt("id")
 		if err != nil {
@@ -30,15 +38,51 @@ var updateCmd = &cobra.Command{
 		
 		description, _ := cmd.Flags().GetString("description")
 		amount, _ := cmd.Flags().GetFloat64("amount")
-		if description == "" && amount <= 0 {
-			fmt.Println("Error: At least one of description or amount must be provided.")
+		category, _ := cmd.Flags().GetString("category")
+		currency, _ := cmd.Flags().GetString("currency")
+		paidBy, _ := cmd.Flags().GetString("paid-by")
+		split, _ := cmd.Flags().GetString("split")
+		with, _ := cmd.Flags().GetString("with")
+		receipt, _ := cmd.Flags().GetString("receipt")
+		notesChanged := cmd.Flags().Changed("notes")
+		if description == "" && amount <= 0 && category == "" && currency == "" && paidBy == "" && with == "" && !notesChanged && receipt == "" {
+			fmt.Println("Error: At least one of description, amount, category, currency, paid-by, with, notes or receipt must be provided.")
 			return
 		}
+		if receipt != "" {
+			if err := expense.CheckReceipt(receipt); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+		if with == "" {
+			split = ""
+		}
 		
-		err = expense.UpdateExpense(id, description, amount)
-		if err != nil {
-			fmt.Printf("Error updating expense with ID %d: %v\n", id, err)
-			return
+		// Core fields and the split are checked and saved together, so a
+		// bad split leaves the amount as it was
+		if description != "" || amount > 0 || category != "" || currency != "" || paidBy != "" || with != "" {
+			err = expense.UpdateExpense(id, description, amount, category, currency, paidBy, split, with)
+			if err != nil {
+				fmt.Printf("Error updating expense with ID %d: %v\n", id, err)
+				return
+			}
+		}
+		if notesChanged {
+			notes, _ := cmd.Flags().GetString("notes")
+			err = expense.SetNotes(id, notes)
+			if err != nil {
+				fmt.Printf("Error updating notes of expense with ID %d: %v\n", id, err)
+				return
+			}
+		}
+		if receipt != "" {
+			stored, err := expense.AttachReceipt(id, receipt)
+			if err != nil {
+				fmt.Printf("Error attaching receipt to expense with ID %d: %v\n", id, err)
+				return
+			}
+			fmt.Println("Receipt stored at", stored)
 		}
 		fmt.Println("Expense updated successfully!")
 	},
@@ -51,6 +95,13 @@ func init() {
 	updateCmd.Flags().IntP("id", "i", 0, "ID of the expense to update (required)")
 	updateCmd.Flags().StringP("description", "d", "", "New description of the expense")
 	updateCmd.Flags().Float64P("amount", "a", 0, "New amount of the expense")
+	updateCmd.Flags().StringP("category", "c", "", "New category of the expense")
+	updateCmd.Flags().String("currency", "", "New currency of the expense, e.g. EUR")
+	updateCmd.Flags().StringP("notes", "N", "", "New notes (an empty value clears them)")
+	updateCmd.Flags().StringP("receipt", "r", "", "File to attach as the receipt, replacing any earlier one")
+	updateCmd.Flags().String("paid-by", "", "Who paid, for a shared expense")
+	updateCmd.Flags().String("split", expense.SplitEqual, "How to split the expense: equal, shares or exact (used with --with)")
+	updateCmd.Flags().String("with", "", "New participants, as for add")
 
 	// Mark the ID flag as required
 	updateCmd.MarkFlagRequired("id")
@@ -0,0 +1,188 @@
+package expense
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Budget is a spending limit for one category in one month
+type Budget struct {
+	Category string  `json:"category"`
+	Month    string  `json:"month"` // YYYY-MM
+	Amount   float64 `json:"amount"`
+}
+
+// CategorySpend compares what was spent in a category with its budget
+type CategorySpend struct {
+	Category  string
+	Spent     float64
+	Budget    float64
+	HasBudget bool
+}
+
+// Over reports whether spending has gone past the budget
+func (c CategorySpend) Over() bool {
+	return c.HasBudget && c.Spent > c.Budget
+}
+
+const budgetFile = "budgets.json"
+
+// MonthLayout is the format used for budget months
+const MonthLayout = "2006-01"
+
+// NormalizeCategory lowercases and trims a category name
+func NormalizeCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+// CategoryLabel is the name shown for a category, including the empty one
+func CategoryLabel(category string) string {
+	if category == "" {
+		return "uncategorized"
+	}
+	return category
+}
+
+// LoadBudgets loads all budgets from the JSON file
+func LoadBudgets() ([]Budget, error) {
+	data, err := os.ReadFile(budgetFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Budget{}, nil
+		}
+		return nil, fmt.Errorf("error reading budgets file: %v", err)
+	}
+	var budgets []Budget
+	err = json.Unmarshal(data, &budgets)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling budgets: %v", err)
+	}
+	return budgets, nil
+}
+
+// SetBudget creates or replaces the budget for a category and month
+func SetBudget(Category string, Month string, Amount float64) error {
+	Category = NormalizeCategory(Category)
+	if Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if _, err := time.Parse(MonthLayout, Month); err != nil {
+		return fmt.Errorf("invalid month %q, expected YYYY-MM", Month)
+	}
+	if Amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	budgets, err := LoadBudgets()
+	if err != nil {
+		return err
+	}
+	found := false
+	for i, b := range budgets {
+		if b.Category == Category && b.Month == Month {
+			budgets[i].Amount = Amount
+			found = true
+		}
+	}
+	if !found {
+		budgets = append(budgets, Budget{Category: Category, Month: Month, Amount: Amount})
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		if budgets[i].Month != budgets[j].Month {
+			return budgets[i].Month < budgets[j].Month
+		}
+		return budgets[i].Category < budgets[j].Category
+	})
+	data, err := json.MarshalIndent(budgets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling budgets: %v", err)
+	}
+	err = os.WriteFile(budgetFile, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing budgets to file: %v", err)
+	}
+	return nil
+}
+
+// CategoryStatus returns what was spent in a category during a month
+// (YYYY-MM) along with that month's budget, if one is set
+func CategoryStatus(Category string, Month string) (CategorySpend, error) {
+	p, err := ParseMonth(Month)
+	if err != nil {
+		return CategorySpend{}, err
+	}
+	spending, err := SpendingByCategory(p, "")
+	if err != nil {
+		return CategorySpend{}, err
+	}
+	Category = NormalizeCategory(Category)
+	for _, s := range spending {
+		if s.Category == Category {
+			return s, nil
+		}
+	}
+	return CategorySpend{Category: Category}, nil
+}
+
+// SpendingByCategory totals expenses per category for a period, converted
+// into Currency (the base currency when empty). Budgets are only attached
+// when the period is a single calendar month; they are set in the base
+// currency and converted at the month's latest rate. Categories that have
+// a budget but no spending are included so they show up in summaries.
+func SpendingByCategory(p Period, Currency string) ([]CategorySpend, error) {
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return nil, fmt.Errorf("error loading expenses: %v", err)
+	}
+	converter, err := NewConverter()
+	if err != nil {
+		return nil, err
+	}
+	totals := map[string]*CategorySpend{}
+	get := func(category string) *CategorySpend {
+		if totals[category] == nil {
+			totals[category] = &CategorySpend{Category: category}
+		}
+		return totals[category]
+	}
+	for _, e := range expenses {
+		if e.Settlement || !p.Contains(e.Date) {
+			continue
+		}
+		amount, err := converter.Convert(e, Currency)
+		if err != nil {
+			return nil, err
+		}
+		get(e.Category).Spent += amount
+	}
+	if Month, ok := p.Month(); ok {
+		budgets, err := LoadBudgets()
+		if err != nil {
+			return nil, err
+		}
+		if Currency == "" {
+			Currency = converter.Base
+		}
+		lastDay := p.To
+		for _, b := range budgets {
+			if b.Month == Month {
+				amount, err := converter.ConvertAmount(b.Amount, converter.Base, Currency, lastDay)
+				if err != nil {
+					return nil, err
+				}
+				s := get(b.Category)
+				s.Budget, s.HasBudget = amount, true
+			}
+		}
+	}
+
+	result := make([]CategorySpend, 0, len(totals))
+	for _, s := range totals {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Category < result[j].Category })
+	return result, nil
+}
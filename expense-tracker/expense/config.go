@@ -0,0 +1,65 @@
+package expense
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Config holds settings shared by all commands
+type Config struct {
+	BaseCurrency string `json:"base_currency"`
+}
+
+const configFile = "config.json"
+
+// DefaultBaseCurrency is used until a base currency is configured
+const DefaultBaseCurrency = "USD"
+
+// LoadConfig loads the config file, filling in defaults
+func LoadConfig() (Config, error) {
+	config := Config{BaseCurrency: DefaultBaseCurrency}
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return config, fmt.Errorf("error reading config file: %v", err)
+	}
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return config, fmt.Errorf("error unmarshalling config: %v", err)
+	}
+	if config.BaseCurrency == "" {
+		config.BaseCurrency = DefaultBaseCurrency
+	}
+	return config, nil
+}
+
+// SaveConfig writes the config file
+func SaveConfig(config Config) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling config: %v", err)
+	}
+	err = os.WriteFile(configFile, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing config to file: %v", err)
+	}
+	return nil
+}
+
+// NormalizeCurrency checks and upper-cases an ISO 4217 code such as EUR
+func NormalizeCurrency(Currency string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(Currency))
+	if len(code) != 3 {
+		return "", fmt.Errorf("invalid currency %q, expected a 3-letter code like EUR", Currency)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("invalid currency %q, expected a 3-letter code like EUR", Currency)
+		}
+	}
+	return code, nil
+}
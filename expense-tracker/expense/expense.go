@@ -11,6 +11,15 @@ type Expense struct {
 	ID		  	int    `json:"id"`
 	Description string    `json:"description"`
 	Amount     	float64   `json:"amount"`
+	Category    string    `json:"category,omitempty"`
+	Currency    string    `json:"currency,omitempty"` // empty means the base currency
+	RecurringID int       `json:"recurring_id,omitempty"` // set on occurrences of a recurring expense
+	PaidBy      string    `json:"paid_by,omitempty"`     // who paid a shared expense
+	SplitMode   string    `json:"split_mode,omitempty"`  // equal, shares or exact
+	Splits      []Split   `json:"splits,omitempty"`      // who the cost is split between
+	Settlement  bool      `json:"settlement,omitempty"`  // a transfer between people, not spending
+	Notes       string    `json:"notes,omitempty"`
+	Receipt     string    `json:"receipt,omitempty"`     // path of the attached receipt
 	Date       	time.Time `json:"date"`
 }
 
@@ -36,57 +45,101 @@ func LoadExpenses() ([]Expense, error) {
 	return expenses, nil
 }
 
-// Add a new expense to the JSON file
-func AddExpense(Description string, Amount float64) (error,int) {
-	expenses, err := LoadExpenses()
+// saveExpenses writes the whole expense list back to the JSON file
+func saveExpenses(expenses []Expense) error {
+	data, err := json.MarshalIndent(expenses, "", "  ")
 	if err != nil {
-		return err, 0
+		return fmt.Errorf("error marshalling expenses: %v", err)
 	}
-	// Create a new expense with a unique ID
+	err = os.WriteFile(expenseFile, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing expenses to file: %v", err)
+	}
+	return nil
+}
+
+// nextID returns an ID one past the highest one in use
+func nextID(expenses []Expense) int {
 	newId := 1
 	for _, expense := range expenses {
 		if expense.ID >= newId {
-			newId = expense.ID +1
+			newId = expense.ID + 1
 		}
 	}
+	return newId
+}
+
+// Add a new expense to the JSON file
+// An empty Currency means the configured base currency
+func AddExpense(Description string, Amount float64, Category string, Currency string) (error,int) {
+	Currency, err := resolveCurrency(Currency)
+	if err != nil {
+		return err, 0
+	}
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return err, 0
+	}
+	// Create a new expense with a unique ID
+	newId := nextID(expenses)
 	currentTime := time.Now()
 	newExpense := Expense{
 		ID:		  newId,
 		Description: Description,
 		Amount:      Amount,
+		Category:    NormalizeCategory(Category),
+		Currency:    Currency,
 		Date:		currentTime,
 	}
 	expenses = append(expenses, newExpense)
-	// Marshal the updated expenses slice to JSON
-	data, err := json.MarshalIndent(expenses, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling expenses: %v", err),0
-	}
-	err = os.WriteFile(expenseFile, data, 0644)
-	if err != nil {
-		return fmt.Errorf("error writing expenses to file: %v", err),0
+	if err := saveExpenses(expenses); err != nil {
+		return err, 0
 	}
 	return nil, newId
 }
 
-// Summary of expenses
+// resolveCurrency validates a currency code, defaulting to the base currency
+func resolveCurrency(Currency string) (string, error) {
+	if Currency == "" {
+		config, err := LoadConfig()
+		if err != nil {
+			return "", err
+		}
+		return config.BaseCurrency, nil
+	}
+	return NormalizeCurrency(Currency)
+}
+
+// Summary of expenses in the base currency, optionally for a month of the
+// current year
 func Summary(month ...int) (float64, error) {
+	if (month != nil && len(month) > 0) {
+		return SummaryIn("", MonthPeriod(time.Now().Year(), time.Month(month[0])))
+	}
+	return SummaryIn("", Period{})
+}
+
+// SummaryIn totals expenses in a period converted into Currency (the base
+// currency when empty), each at the exchange rate of its own date
+func SummaryIn(Currency string, p Period) (float64, error) {
 	expenses, err := LoadExpenses()
 	if err != nil {
 		return 0, fmt.Errorf("error loading expenses: %v", err)
 	}
+	converter, err := NewConverter()
+	if err != nil {
+		return 0, err
+	}
 	totalAmount := 0.0
-	if (month != nil && len(month) > 0) {
-		// If month is provided, filter expenses by month
-		for _, expense := range expenses {
-			if expense.Date.Month() == time.Month(month[0]) {
-				totalAmount += expense.Amount
-			}
-		} 
-	} else { 
-		for _, expense := range expenses{
-			totalAmount += expense.Amount
+	for _, expense := range expenses {
+		if expense.Settlement || !p.Contains(expense.Date) {
+			continue
+		}
+		amount, err := converter.Convert(expense, Currency)
+		if err != nil {
+			return 0, err
 		}
+		totalAmount += amount
 	}
 	return totalAmount, nil
 }
@@ -111,53 +164,68 @@ func DeleteExpense(ID int) error {
 	if !found {
 		return fmt.Errorf("expense with ID %d not found", ID)
 	}
-	data, err := json.MarshalIndent(updatedExpenses, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling expenses: %v", err)
+	if err := saveExpenses(updatedExpenses); err != nil {
+		return err
 	}
-	err = os.WriteFile(expenseFile, data, 0644)
+	// The expense is gone, so its receipt goes too
+	return removeReceipt(ID)
+}
+
+// SetNotes replaces the notes of an expense
+func SetNotes(ID int, Notes string) error {
+	expenses, err := LoadExpenses()
 	if err != nil {
-		return fmt.Errorf("error writing expenses to file: %v", err)
+		return fmt.Errorf("error loading expenses: %v", err)
 	}
-	return nil
+	for i, e := range expenses {
+		if e.ID == ID {
+			expenses[i].Notes = Notes
+			return saveExpenses(expenses)
+		}
+	}
+	return fmt.Errorf("expense with ID %d not found", ID)
 }
 
-// UpdateExpense updates an existing expense by ID
-func UpdateExpense(ID int, Description string, Amount float64) error {
+// UpdateExpense updates an existing expense by ID, keeping its date
+// An empty Category or Currency keeps the current one. PaidBy, Mode and
+// With change the split as for ShareExpense; the split is checked against
+// the new amount before anything is saved, so a failed update changes
+// nothing.
+func UpdateExpense(ID int, Description string, Amount float64, Category string, Currency string, PaidBy string, Mode string, With string) error {
+	if Currency != "" {
+		var err error
+		Currency, err = NormalizeCurrency(Currency)
+		if err != nil {
+			return err
+		}
+	}
 	expenses,err := LoadExpenses()
 	if err != nil {
 		return fmt.Errorf("error loading expenses: %v", err)
 	}
-	// Check if the expense with the given ID exists
-	found := false
-	var updatedExpenses []Expense
-	for _, e := range expenses {
+	for i, e := range expenses {
 		if e.ID != ID {
-			updatedExpenses = append(updatedExpenses, e)
-		} else {
-			found = true
-			// Update the expense details
-			if Description == "" {
-				Description = e.Description // Keep the old description if new one is empty
-			} else if Amount <= 0 {
-				Amount = e.Amount // Keep the old amount if new one is not provided
-			}
+			continue
+		}
+		if Description != "" {
 			e.Description = Description
+		}
+		if Amount > 0 {
 			e.Amount = Amount
-			e.Date = time.Now() // Update the date to current time
-			updatedExpenses = append(updatedExpenses, e)
 		}
+		if Category != "" {
+			e.Category = NormalizeCategory(Category)
+		}
+		if Currency != "" {
+			e.Currency = Currency
+		}
+		if PaidBy != "" || Mode != "" || Amount > 0 {
+			if err := applyShare(&e, PaidBy, Mode, With); err != nil {
+				return err
+			}
+		}
+		expenses[i] = e
+		return saveExpenses(expenses)
 	}
-	if !found {
-		return fmt.Errorf("expense with ID %d not found", ID)
-	}
-	data, err := json.MarshalIndent(updatedExpenses, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling expenses: %v", err)
-	}
-	err = os.WriteFile(expenseFile, data, 0644)
-	if err != nil {
-		return fmt.Errorf("error writing expenses to file: %v", err)
-	}
-	return nil
+	return fmt.Errorf("expense with ID %d not found", ID)
 }
\ No newline at end of file
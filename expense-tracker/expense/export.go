@@ -0,0 +1,86 @@
+package expense
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Filter selects expenses by date range and category. Zero values match
+// everything; From and To are inclusive days.
+type Filter struct {
+	From     time.Time
+	To       time.Time
+	Category string
+}
+
+// Match reports whether an expense passes the filter
+func (f Filter) Match(e Expense) bool {
+	if !(Period{From: f.From, To: f.To}).Contains(e.Date) {
+		return false
+	}
+	if f.Category != "" && e.Category != NormalizeCategory(f.Category) {
+		return false
+	}
+	return true
+}
+
+// FilterExpenses returns the expenses that pass the filter
+func FilterExpenses(expenses []Expense, f Filter) []Expense {
+	var result []Expense
+	for _, e := range expenses {
+		if f.Match(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// ParseDay parses a YYYY-MM-DD date in local time
+func ParseDay(s string) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return day, nil
+}
+
+// ExportCSV writes expenses as CSV with a header row
+func ExportCSV(w io.Writer, expenses []Expense) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"id", "date", "description", "amount", "currency", "category"})
+	for _, e := range expenses {
+		writer.Write([]string{
+			strconv.Itoa(e.ID),
+			e.Date.Format("2006-01-02"),
+			e.Description,
+			strconv.FormatFloat(e.Amount, 'f', 2, 64),
+			e.Currency,
+			e.Category,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing CSV: %v", err)
+	}
+	return nil
+}
+
+// ExportJSON writes expenses as an indented JSON array
+func ExportJSON(w io.Writer, expenses []Expense) error {
+	if expenses == nil {
+		expenses = []Expense{}
+	}
+	data, err := json.MarshalIndent(expenses, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling expenses: %v", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	if err != nil {
+		return fmt.Errorf("error writing JSON: %v", err)
+	}
+	return nil
+}
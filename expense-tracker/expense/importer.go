@@ -0,0 +1,371 @@
+package expense
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVMapping says which columns of a bank statement CSV hold which fields.
+// Columns are header names, or 1-based column numbers when the file has no
+// header row.
+type CSVMapping struct {
+	Date        string
+	Description string
+	Amount      string
+	Category    string // optional
+	DateFormat  string // e.g. YYYY-MM-DD or DD/MM/YYYY
+	Delimiter   rune
+	NoHeader    bool
+	// NegativeIsExpense is for statements where money going out is
+	// negative; positive rows (income) are then skipped
+	NegativeIsExpense bool
+}
+
+// DefaultDateFormat is the date format assumed for imports and exports
+const DefaultDateFormat = "YYYY-MM-DD"
+
+// ParseCSV reads expenses from a bank statement CSV. It returns the parsed
+// expenses and the number of rows skipped because they were not expenses
+// (zero or income amounts).
+func ParseCSV(r io.Reader, m CSVMapping) ([]Expense, int, error) {
+	reader := csv.NewReader(r)
+	if m.Delimiter != 0 {
+		reader.Comma = m.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading CSV: %v", err)
+	}
+	if len(rows) == 0 {
+		return nil, 0, nil
+	}
+
+	var header []string
+	if !m.NoHeader {
+		header, rows = rows[0], rows[1:]
+	}
+	dateCol, err := columnIndex(header, m.Date, "date")
+	if err != nil {
+		return nil, 0, err
+	}
+	descCol, err := columnIndex(header, m.Description, "description")
+	if err != nil {
+		return nil, 0, err
+	}
+	amountCol, err := columnIndex(header, m.Amount, "amount")
+	if err != nil {
+		return nil, 0, err
+	}
+	categoryCol := -1
+	if m.Category != "" {
+		categoryCol, err = columnIndex(header, m.Category, "category")
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	layout := dateLayout(m.DateFormat)
+
+	var expenses []Expense
+	skipped := 0
+	for i, row := range rows {
+		line := i + 1
+		if !m.NoHeader {
+			line++
+		}
+		field := func(col int) string {
+			if col < 0 || col >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[col])
+		}
+		if strings.Join(row, "") == "" {
+			continue
+		}
+		date, err := time.ParseInLocation(layout, field(dateCol), time.Local)
+		if err != nil {
+			return nil, 0, fmt.Errorf("line %d: invalid date %q, expected %s", line, field(dateCol), dateFormatOrDefault(m.DateFormat))
+		}
+		amount, err := parseAmount(field(amountCol))
+		if err != nil {
+			return nil, 0, fmt.Errorf("line %d: %v", line, err)
+		}
+		if m.NegativeIsExpense {
+			amount = -amount
+		}
+		if amount <= 0 {
+			skipped++
+			continue
+		}
+		expenses = append(expenses, Expense{
+			Description: field(descCol),
+			Amount:      amount,
+			Category:    NormalizeCategory(field(categoryCol)),
+			Date:        date,
+		})
+	}
+	return expenses, skipped, nil
+}
+
+// ParseOFX reads the transactions of an OFX statement. Both the SGML (1.x)
+// and XML (2.x) flavours are handled. Debits (negative amounts) become
+// expenses; credits are counted as skipped.
+func ParseOFX(r io.Reader) ([]Expense, int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading OFX: %v", err)
+	}
+	text := string(data)
+	upper := strings.ToUpper(text)
+	// CURDEF is the statement's default currency
+	currency, _ := NormalizeCurrency(ofxValue(text, "CURDEF"))
+
+	var expenses []Expense
+	skipped := 0
+	found := false
+	for {
+		start := strings.Index(upper, "<STMTTRN>")
+		if start < 0 {
+			break
+		}
+		found = true
+		end := strings.Index(upper[start:], "</STMTTRN>")
+		if end < 0 {
+			end = len(upper) - start
+		}
+		block := text[start : start+end]
+		text, upper = text[start+end:], upper[start+end:]
+		if len(text) > 0 {
+			// Step past the tag we stopped at
+			text, upper = text[1:], upper[1:]
+		}
+
+		posted := ofxValue(block, "DTPOSTED")
+		date, err := parseOFXDate(posted)
+		if err != nil {
+			return nil, 0, err
+		}
+		amount, err := parseAmount(ofxValue(block, "TRNAMT"))
+		if err != nil {
+			return nil, 0, fmt.Errorf("transaction on %s: %v", posted, err)
+		}
+		if amount >= 0 {
+			skipped++
+			continue
+		}
+		description := ofxValue(block, "NAME")
+		if description == "" {
+			description = ofxValue(block, "MEMO")
+		}
+		expenses = append(expenses, Expense{
+			Description: description,
+			Amount:      -amount,
+			Currency:    currency,
+			Date:        date,
+		})
+	}
+	if !found {
+		return nil, 0, fmt.Errorf("no transactions found, is this an OFX file?")
+	}
+	return expenses, skipped, nil
+}
+
+// ImportResult lists what an import added and what it left out as duplicates
+type ImportResult struct {
+	Added      []Expense
+	Duplicates []Expense
+}
+
+// ImportExpenses adds records that are not already present. A record is a
+// duplicate when an existing expense has the same date, amount and
+// description. Each existing expense accounts for one record, so two
+// identical purchases on the same day both import, and importing the same
+// statement again adds nothing. Records without a
+// currency get Currency, or the base currency when that is empty. With
+// DryRun nothing is written.
+func ImportExpenses(records []Expense, Currency string, DryRun bool) (ImportResult, error) {
+	var result ImportResult
+	Currency, err := resolveCurrency(Currency)
+	if err != nil {
+		return result, err
+	}
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return result, err
+	}
+	existing := map[string]int{}
+	for _, e := range expenses {
+		existing[duplicateKey(e)]++
+	}
+	id := nextID(expenses)
+	for _, r := range records {
+		key := duplicateKey(r)
+		if existing[key] > 0 {
+			existing[key]--
+			result.Duplicates = append(result.Duplicates, r)
+			continue
+		}
+		if r.Currency == "" {
+			r.Currency = Currency
+		}
+		r.ID = id
+		id++
+		expenses = append(expenses, r)
+		result.Added = append(result.Added, r)
+	}
+	if DryRun || len(result.Added) == 0 {
+		return result, nil
+	}
+	return result, saveExpenses(expenses)
+}
+
+// duplicateKey identifies an expense by day, amount in cents and description
+func duplicateKey(e Expense) string {
+	return fmt.Sprintf("%s|%d|%s", e.Date.Format("2006-01-02"), int64(math.Round(e.Amount*100)),
+		strings.ToLower(strings.Join(strings.Fields(e.Description), " ")))
+}
+
+// columnIndex resolves a column given by header name or 1-based number
+func columnIndex(header []string, column string, field string) (int, error) {
+	if column == "" {
+		return 0, fmt.Errorf("no column given for %s", field)
+	}
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 {
+			return 0, fmt.Errorf("column numbers start at 1, got %d for %s", n, field)
+		}
+		return n - 1, nil
+	}
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), column) {
+			return i, nil
+		}
+	}
+	if header == nil {
+		return 0, fmt.Errorf("column %q for %s must be a number when the file has no header", column, field)
+	}
+	return 0, fmt.Errorf("column %q for %s not found in header %v", column, field, header)
+}
+
+// dateLayout turns a format like DD/MM/YYYY into a Go time layout
+func dateLayout(format string) string {
+	r := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+	return r.Replace(strings.ToUpper(dateFormatOrDefault(format)))
+}
+
+func dateFormatOrDefault(format string) string {
+	if format == "" {
+		return DefaultDateFormat
+	}
+	return format
+}
+
+// parseAmount reads amounts as banks write them: with currency symbols,
+// thousands separators, decimal commas, or parentheses for negatives. A
+// lone comma followed by three digits could be either separator, so
+// "1,234" is rejected rather than guessed.
+func parseAmount(s string) (float64, error) {
+	original := s
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	s = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' || r == '-' || r == '+' {
+			return r
+		}
+		return -1
+	}, s)
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case dot >= 0 && comma >= 0:
+		// Whichever separator comes last is the decimal one
+		if comma > dot {
+			s = strings.Replace(strings.ReplaceAll(s, ".", ""), ",", ".", 1)
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case comma >= 0:
+		switch {
+		case strings.Count(s, ",") > 1:
+			s = strings.ReplaceAll(s, ",", "")
+		case len(s)-comma-1 == 3:
+			return 0, fmt.Errorf("ambiguous amount %q, write it as 1234, 1234.00 or 1.234,00", original)
+		default:
+			s = strings.Replace(s, ",", ".", 1)
+		}
+	case strings.Count(s, ".") > 1:
+		s = strings.ReplaceAll(s, ".", "")
+	}
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", original)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// ofxValue returns the value of a leaf element. In SGML OFX the closing
+// tag is optional, so the value runs to the next tag or line break.
+func ofxValue(block string, tag string) string {
+	upper := strings.ToUpper(block)
+	i := strings.Index(upper, "<"+tag+">")
+	if i < 0 {
+		return ""
+	}
+	value := block[i+len(tag)+2:]
+	if j := strings.IndexAny(value, "<\r\n"); j >= 0 {
+		value = value[:j]
+	}
+	value = strings.TrimSpace(value)
+	return strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">").Replace(value)
+}
+
+// parseOFXDate reads YYYYMMDD[HHMMSS[.XXX]][[offset:TZ]], keeping the day
+func parseOFXDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("invalid OFX date %q", s)
+	}
+	date, err := time.ParseInLocation("20060102", s[:8], time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid OFX date %q", s)
+	}
+	return date, nil
+}
+
+// ParseStatement reads a bank statement in the given format ("csv" or
+// "ofx"). An empty format is guessed from the content.
+func ParseStatement(r io.Reader, Format string, m CSVMapping) ([]Expense, int, error) {
+	br := bufio.NewReader(r)
+	if Format == "" {
+		Format = sniffFormat(br)
+	}
+	switch strings.ToLower(Format) {
+	case "csv":
+		return ParseCSV(br, m)
+	case "ofx", "qfx":
+		return ParseOFX(br)
+	}
+	return nil, 0, fmt.Errorf("unknown import format %q, expected csv or ofx", Format)
+}
+
+// sniffFormat guesses whether a statement is OFX from its first lines
+func sniffFormat(r *bufio.Reader) string {
+	peek, _ := r.Peek(512)
+	head := strings.ToUpper(string(peek))
+	if strings.Contains(head, "OFXHEADER") || strings.Contains(head, "<OFX>") {
+		return "ofx"
+	}
+	return "csv"
+}
@@ -0,0 +1,170 @@
+package expense
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Period is an inclusive range of days. A zero From or To leaves that end
+// open.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// MonthPeriod is one calendar month of one year
+func MonthPeriod(year int, month time.Month) Period {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	return Period{From: from, To: from.AddDate(0, 1, -1)}
+}
+
+// QuarterPeriod is one quarter (1-4) of one year
+func QuarterPeriod(year int, quarter int) Period {
+	from := time.Date(year, time.Month(3*(quarter-1)+1), 1, 0, 0, 0, 0, time.Local)
+	return Period{From: from, To: from.AddDate(0, 3, -1)}
+}
+
+// YearPeriod is one calendar year
+func YearPeriod(year int) Period {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	return Period{From: from, To: from.AddDate(1, 0, -1)}
+}
+
+// ParseMonth parses a YYYY-MM month into its period
+func ParseMonth(s string) (Period, error) {
+	t, err := time.Parse(MonthLayout, s)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid month %q, expected YYYY-MM", s)
+	}
+	return MonthPeriod(t.Year(), t.Month()), nil
+}
+
+// ResolvePeriod builds a period from command-line style options: a
+// From/To day range (YYYY-MM-DD), or a Year narrowed by Month (1-12) or
+// Quarter (1-4). From without To runs through today. Month or Quarter
+// without a Year means the current year.
+// With nothing set the period is open on both ends.
+func ResolvePeriod(From string, To string, Year int, Month int, Quarter int) (Period, error) {
+	var p Period
+	var err error
+	if From != "" || To != "" {
+		if Year != 0 || Month != 0 || Quarter != 0 {
+			return p, fmt.Errorf("use either --from/--to or --year/--month/--quarter, not both")
+		}
+		if From != "" {
+			if p.From, err = ParseDay(From); err != nil {
+				return p, err
+			}
+		}
+		if To != "" {
+			if p.To, err = ParseDay(To); err != nil {
+				return p, err
+			}
+		} else {
+			p.To, _ = ParseDay(time.Now().Format("2006-01-02"))
+		}
+		if !p.From.IsZero() && !p.To.IsZero() && p.To.Before(p.From) {
+			return p, fmt.Errorf("--to %s is before --from %s", To, From)
+		}
+		return p, nil
+	}
+	if Month != 0 && Quarter != 0 {
+		return p, fmt.Errorf("use either --month or --quarter, not both")
+	}
+	if Month < 0 || Month > 12 {
+		return p, fmt.Errorf("month must be between 1 and 12")
+	}
+	if Quarter < 0 || Quarter > 4 {
+		return p, fmt.Errorf("quarter must be between 1 and 4")
+	}
+	if Year == 0 && (Month != 0 || Quarter != 0) {
+		Year = time.Now().Year()
+	}
+	switch {
+	case Month != 0:
+		return MonthPeriod(Year, time.Month(Month)), nil
+	case Quarter != 0:
+		return QuarterPeriod(Year, Quarter), nil
+	case Year != 0:
+		return YearPeriod(Year), nil
+	}
+	return p, nil
+}
+
+// Bounded reports whether both ends of the period are set
+func (p Period) Bounded() bool {
+	return !p.From.IsZero() && !p.To.IsZero()
+}
+
+// Contains reports whether t falls on one of the period's days
+func (p Period) Contains(t time.Time) bool {
+	day := t.Format("2006-01-02")
+	if !p.From.IsZero() && day < p.From.Format("2006-01-02") {
+		return false
+	}
+	if !p.To.IsZero() && day > p.To.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
+
+// Days is the number of days in a bounded period
+func (p Period) Days() int {
+	return int(p.To.Sub(p.From).Hours()/24+0.5) + 1
+}
+
+// Month returns the period as YYYY-MM when it is exactly one calendar month
+func (p Period) Month() (string, bool) {
+	if !p.Bounded() || !p.sameDays(MonthPeriod(p.From.Year(), p.From.Month())) {
+		return "", false
+	}
+	return p.From.Format(MonthLayout), true
+}
+
+// sameDays reports whether two periods cover the same days
+func (p Period) sameDays(other Period) bool {
+	return p.From.Format("2006-01-02") == other.From.Format("2006-01-02") &&
+		p.To.Format("2006-01-02") == other.To.Format("2006-01-02")
+}
+
+// wholeMonths returns how many calendar months the period spans when it
+// starts on a first and ends on a last day of a month
+func (p Period) wholeMonths() int {
+	if !p.Bounded() || p.From.Day() != 1 || p.To.AddDate(0, 0, 1).Day() != 1 {
+		return 0
+	}
+	return (p.To.Year()-p.From.Year())*12 + int(p.To.Month()-p.From.Month()) + 1
+}
+
+// Previous is the period of the same length just before this one. Whole
+// months step back by calendar months, so the month before March is all of
+// February.
+func (p Period) Previous() Period {
+	if months := p.wholeMonths(); months > 0 {
+		from := p.From.AddDate(0, -months, 0)
+		return Period{From: from, To: p.From.AddDate(0, 0, -1)}
+	}
+	days := p.Days()
+	return Period{From: p.From.AddDate(0, 0, -days), To: p.From.AddDate(0, 0, -1)}
+}
+
+// String describes the period for headings
+func (p Period) String() string {
+	if month, ok := p.Month(); ok {
+		return month
+	}
+	switch {
+	case p.Bounded() && p.sameDays(YearPeriod(p.From.Year())):
+		return strconv.Itoa(p.From.Year())
+	case p.Bounded() && p.wholeMonths() == 3 && (p.From.Month()-1)%3 == 0:
+		return fmt.Sprintf("%d-Q%d", p.From.Year(), (p.From.Month()-1)/3+1)
+	case p.Bounded():
+		return p.From.Format("2006-01-02") + " to " + p.To.Format("2006-01-02")
+	case !p.From.IsZero():
+		return "since " + p.From.Format("2006-01-02")
+	case !p.To.IsZero():
+		return "until " + p.To.Format("2006-01-02")
+	}
+	return "all time"
+}
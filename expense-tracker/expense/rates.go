@@ -0,0 +1,267 @@
+package expense
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rates is a table of exchange rates by day. Each day maps a currency to
+// how many units of it one unit of Base buys.
+type Rates struct {
+	Base  string                        `json:"base"`
+	Rates map[string]map[string]float64 `json:"rates"` // YYYY-MM-DD -> currency -> rate
+}
+
+const ratesFile = "rates.json"
+
+// LoadRates loads the exchange rate table from the rates file
+func LoadRates() (Rates, error) {
+	rates := Rates{Rates: map[string]map[string]float64{}}
+	data, err := os.ReadFile(ratesFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rates, nil
+		}
+		return rates, fmt.Errorf("error reading rates file: %v", err)
+	}
+	err = json.Unmarshal(data, &rates)
+	if err != nil {
+		return rates, fmt.Errorf("error unmarshalling rates: %v", err)
+	}
+	if rates.Rates == nil {
+		rates.Rates = map[string]map[string]float64{}
+	}
+	return rates, nil
+}
+
+// SaveRates writes the exchange rate table to the rates file
+func SaveRates(rates Rates) error {
+	data, err := json.MarshalIndent(rates, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling rates: %v", err)
+	}
+	err = os.WriteFile(ratesFile, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing rates to file: %v", err)
+	}
+	return nil
+}
+
+// Days returns the days that have rates, oldest first
+func (r Rates) Days() []string {
+	days := make([]string, 0, len(r.Rates))
+	for day := range r.Rates {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	return days
+}
+
+// Rate returns how many units of Currency one unit of Base bought on the
+// given day, using the most recent rate on or before that day
+func (r Rates) Rate(Currency string, Date time.Time) (float64, error) {
+	if Currency == r.Base {
+		return 1, nil
+	}
+	day := Date.Format("2006-01-02")
+	days := r.Days()
+	for i := len(days) - 1; i >= 0; i-- {
+		if days[i] > day {
+			continue
+		}
+		if rate, ok := r.Rates[days[i]][Currency]; ok {
+			return rate, nil
+		}
+	}
+	return 0, fmt.Errorf("no %s exchange rate on or before %s, run 'rates import' first", Currency, day)
+}
+
+// Merge adds the rates of other, replacing any already held for the same
+// day and currency. Rates quoted against a different base are converted
+// using that day's rate for this table's base.
+func (r *Rates) Merge(other Rates) (int, error) {
+	if r.Base == "" || len(r.Rates) == 0 {
+		r.Base = other.Base
+	}
+	if r.Rates == nil {
+		r.Rates = map[string]map[string]float64{}
+	}
+	count := 0
+	for day, quotes := range other.Rates {
+		factor := 1.0
+		if other.Base != r.Base {
+			// quotes are per unit of other.Base; scale them to per unit of r.Base
+			pivot, ok := quotes[r.Base]
+			if !ok || pivot <= 0 {
+				return count, fmt.Errorf("rates for %s are quoted in %s and have no %s rate to convert with", day, other.Base, r.Base)
+			}
+			factor = 1 / pivot
+			quotes = copyQuotes(quotes)
+			quotes[other.Base] = 1
+		}
+		if r.Rates[day] == nil {
+			r.Rates[day] = map[string]float64{}
+		}
+		for currency, rate := range quotes {
+			if currency == r.Base {
+				continue
+			}
+			if rate <= 0 {
+				return count, fmt.Errorf("invalid %s rate %v on %s", currency, rate, day)
+			}
+			r.Rates[day][currency] = rate * factor
+			count++
+		}
+	}
+	return count, nil
+}
+
+func copyQuotes(quotes map[string]float64) map[string]float64 {
+	c := make(map[string]float64, len(quotes))
+	for k, v := range quotes {
+		c[k] = v
+	}
+	return c
+}
+
+// ParseRates reads a rate table in JSON (the same shape as the rates file,
+// as published by ECB-style rate services) or CSV with date, currency and
+// rate columns. CSV rates are per unit of Base.
+func ParseRates(r io.Reader, Format string, Base string) (Rates, error) {
+	switch strings.ToLower(Format) {
+	case "json":
+		var rates Rates
+		err := json.NewDecoder(r).Decode(&rates)
+		if err != nil {
+			return rates, fmt.Errorf("error reading rates JSON: %v", err)
+		}
+		if Base != "" {
+			rates.Base = Base
+		}
+		return normalizeRates(rates)
+	case "csv":
+		return parseRatesCSV(r, Base)
+	}
+	return Rates{}, fmt.Errorf("unknown rates format %q, expected csv or json", Format)
+}
+
+func parseRatesCSV(r io.Reader, Base string) (Rates, error) {
+	rates := Rates{Base: Base, Rates: map[string]map[string]float64{}}
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return rates, fmt.Errorf("error reading rates CSV: %v", err)
+	}
+	for i, row := range rows {
+		if len(row) < 3 {
+			return rates, fmt.Errorf("line %d: expected date,currency,rate", i+1)
+		}
+		if i == 0 && strings.EqualFold(strings.TrimSpace(row[0]), "date") {
+			continue
+		}
+		day, err := ParseDay(strings.TrimSpace(row[0]))
+		if err != nil {
+			return rates, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(row[2]), 64)
+		if err != nil {
+			return rates, fmt.Errorf("line %d: invalid rate %q", i+1, row[2])
+		}
+		key := day.Format("2006-01-02")
+		if rates.Rates[key] == nil {
+			rates.Rates[key] = map[string]float64{}
+		}
+		rates.Rates[key][strings.TrimSpace(row[1])] = rate
+	}
+	return normalizeRates(rates)
+}
+
+// normalizeRates checks the currency codes and days of a parsed table
+func normalizeRates(rates Rates) (Rates, error) {
+	base, err := NormalizeCurrency(rates.Base)
+	if err != nil {
+		return rates, fmt.Errorf("rates base: %v", err)
+	}
+	result := Rates{Base: base, Rates: map[string]map[string]float64{}}
+	for day, quotes := range rates.Rates {
+		if _, err := ParseDay(day); err != nil {
+			return result, err
+		}
+		result.Rates[day] = map[string]float64{}
+		for currency, rate := range quotes {
+			code, err := NormalizeCurrency(currency)
+			if err != nil {
+				return result, fmt.Errorf("%s: %v", day, err)
+			}
+			result.Rates[day][code] = rate
+		}
+	}
+	return result, nil
+}
+
+// Converter converts expense amounts between currencies
+type Converter struct {
+	Base  string
+	rates *Rates
+}
+
+// NewConverter returns a converter for the configured base currency. The
+// rates file is only read when a conversion actually needs it.
+func NewConverter() (*Converter, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &Converter{Base: config.BaseCurrency}, nil
+}
+
+// CurrencyOf returns the currency of an expense; expenses recorded before
+// currencies existed are in the base currency
+func (c *Converter) CurrencyOf(e Expense) string {
+	if e.Currency == "" {
+		return c.Base
+	}
+	return e.Currency
+}
+
+// Convert returns the amount of an expense in Currency (the base currency
+// when empty), using the rates of the expense's date
+func (c *Converter) Convert(e Expense, Currency string) (float64, error) {
+	if Currency == "" {
+		Currency = c.Base
+	}
+	return c.ConvertAmount(e.Amount, c.CurrencyOf(e), Currency, e.Date)
+}
+
+// ConvertAmount converts Amount from one currency to another using the
+// rates of the given date
+func (c *Converter) ConvertAmount(Amount float64, From string, To string, Date time.Time) (float64, error) {
+	if From == To {
+		return Amount, nil
+	}
+	if c.rates == nil {
+		rates, err := LoadRates()
+		if err != nil {
+			return 0, err
+		}
+		c.rates = &rates
+	}
+	if c.rates.Base == "" {
+		return 0, fmt.Errorf("no exchange rates to convert %s to %s, run 'rates import' first", From, To)
+	}
+	fromRate, err := c.rates.Rate(From, Date)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := c.rates.Rate(To, Date)
+	if err != nil {
+		return 0, err
+	}
+	return Amount / fromRate * toRate, nil
+}
@@ -0,0 +1,109 @@
+package expense
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// receiptDir holds one sub-directory of attachments per expense ID
+const receiptDir = "receipts"
+
+// AttachReceipt copies a file into the receipt directory for an expense
+// and links it, replacing any receipt attached before. It returns the
+// stored path.
+func AttachReceipt(ID int, Path string) (string, error) {
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return "", fmt.Errorf("error loading expenses: %v", err)
+	}
+	for i, e := range expenses {
+		if e.ID != ID {
+			continue
+		}
+		// Copy into a staging directory next to the final one and swap it
+		// in, so a failed copy leaves the old receipt where it was
+		dir := filepath.Join(receiptDir, strconv.Itoa(ID))
+		staging, old := dir+".new", dir+".old"
+		os.RemoveAll(staging)
+		os.RemoveAll(old)
+		if err := copyFile(Path, filepath.Join(staging, filepath.Base(Path))); err != nil {
+			os.RemoveAll(staging)
+			return "", err
+		}
+		hadOld := true
+		if err := os.Rename(dir, old); err != nil {
+			if !os.IsNotExist(err) {
+				os.RemoveAll(staging)
+				return "", fmt.Errorf("error replacing old receipt: %v", err)
+			}
+			hadOld = false
+		}
+		restore := func() {
+			os.RemoveAll(dir)
+			if hadOld {
+				os.Rename(old, dir)
+			}
+		}
+		if err := os.Rename(staging, dir); err != nil {
+			os.RemoveAll(staging)
+			restore()
+			return "", fmt.Errorf("error storing receipt: %v", err)
+		}
+		stored := filepath.Join(dir, filepath.Base(Path))
+		expenses[i].Receipt = stored
+		if err := saveExpenses(expenses); err != nil {
+			restore()
+			return "", err
+		}
+		os.RemoveAll(old)
+		return stored, nil
+	}
+	return "", fmt.Errorf("expense with ID %d not found", ID)
+}
+
+// CheckReceipt makes sure a receipt can be read before an expense is saved
+func CheckReceipt(Path string) error {
+	info, err := os.Stat(Path)
+	if err != nil {
+		return fmt.Errorf("error reading receipt: %v", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("receipt %s is a directory", Path)
+	}
+	return nil
+}
+
+// removeReceipt deletes the attachments of an expense, if it has any
+func removeReceipt(ID int) error {
+	err := os.RemoveAll(filepath.Join(receiptDir, strconv.Itoa(ID)))
+	if err != nil {
+		return fmt.Errorf("error removing receipt: %v", err)
+	}
+	return nil
+}
+
+func copyFile(from, to string) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return fmt.Errorf("error reading receipt: %v", err)
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return fmt.Errorf("error creating receipt directory: %v", err)
+	}
+	out, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("error storing receipt: %v", err)
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error storing receipt: %v", err)
+	}
+	return nil
+}
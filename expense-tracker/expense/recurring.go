@@ -0,0 +1,302 @@
+package expense
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurring defines an expense that repeats, such as rent or a
+// subscription. Occurrences are added to the ledger by SyncRecurring once
+// they are due.
+type Recurring struct {
+	ID          int     `json:"id"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Category    string  `json:"category,omitempty"`
+	Currency    string  `json:"currency,omitempty"`
+	Interval    string  `json:"interval"`      // e.g. monthly or "2 weeks"
+	Start       string  `json:"start"`         // YYYY-MM-DD, the first occurrence
+	End         string  `json:"end,omitempty"` // YYYY-MM-DD, no occurrences after it
+	// Materialized counts the occurrences already added to the ledger
+	Materialized int `json:"materialized"`
+}
+
+const recurringFile = "recurring.json"
+
+// LoadRecurring loads all recurring expense definitions
+func LoadRecurring() ([]Recurring, error) {
+	data, err := os.ReadFile(recurringFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Recurring{}, nil
+		}
+		return nil, fmt.Errorf("error reading recurring file: %v", err)
+	}
+	var recurring []Recurring
+	err = json.Unmarshal(data, &recurring)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling recurring expenses: %v", err)
+	}
+	return recurring, nil
+}
+
+// saveRecurring writes all recurring expense definitions
+func saveRecurring(recurring []Recurring) error {
+	data, err := json.MarshalIndent(recurring, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling recurring expenses: %v", err)
+	}
+	err = os.WriteFile(recurringFile, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing recurring expenses to file: %v", err)
+	}
+	return nil
+}
+
+// AddRecurring validates and saves a new recurring expense definition,
+// returning it with its ID
+func AddRecurring(r Recurring) (Recurring, error) {
+	if r.Description == "" || r.Amount <= 0 {
+		return r, fmt.Errorf("description and a positive amount are required")
+	}
+	if _, _, err := ParseInterval(r.Interval); err != nil {
+		return r, err
+	}
+	start, err := ParseDay(r.Start)
+	if err != nil {
+		return r, err
+	}
+	if r.End != "" {
+		end, err := ParseDay(r.End)
+		if err != nil {
+			return r, err
+		}
+		if end.Before(start) {
+			return r, fmt.Errorf("end %s is before start %s", r.End, r.Start)
+		}
+	}
+	r.Currency, err = resolveCurrency(r.Currency)
+	if err != nil {
+		return r, err
+	}
+	r.Category = NormalizeCategory(r.Category)
+	r.Interval = strings.ToLower(strings.TrimSpace(r.Interval))
+	r.Materialized = 0
+
+	recurring, err := LoadRecurring()
+	if err != nil {
+		return r, err
+	}
+	r.ID = 1
+	for _, existing := range recurring {
+		if existing.ID >= r.ID {
+			r.ID = existing.ID + 1
+		}
+	}
+	recurring = append(recurring, r)
+	return r, saveRecurring(recurring)
+}
+
+// DeleteRecurring removes a recurring definition. Occurrences already in
+// the ledger are kept.
+func DeleteRecurring(ID int) (Recurring, error) {
+	recurring, err := LoadRecurring()
+	if err != nil {
+		return Recurring{}, err
+	}
+	for i, r := range recurring {
+		if r.ID == ID {
+			recurring = append(recurring[:i], recurring[i+1:]...)
+			return r, saveRecurring(recurring)
+		}
+	}
+	return Recurring{}, fmt.Errorf("recurring expense with ID %d not found", ID)
+}
+
+// ParseInterval reads daily, weekly, biweekly, monthly, quarterly, yearly,
+// or "N days|weeks|months|years", returning the unit and count
+func ParseInterval(Interval string) (string, int, error) {
+	s := strings.ToLower(strings.TrimSpace(Interval))
+	switch s {
+	case "daily":
+		return "day", 1, nil
+	case "weekly":
+		return "week", 1, nil
+	case "biweekly", "fortnightly":
+		return "week", 2, nil
+	case "monthly":
+		return "month", 1, nil
+	case "quarterly":
+		return "month", 3, nil
+	case "yearly", "annually":
+		return "year", 1, nil
+	}
+	fields := strings.Fields(s)
+	if len(fields) == 2 {
+		n, err := strconv.Atoi(fields[0])
+		unit := strings.TrimSuffix(fields[1], "s")
+		if err == nil && n > 0 && (unit == "day" || unit == "week" || unit == "month" || unit == "year") {
+			return unit, n, nil
+		}
+	}
+	return "", 0, fmt.Errorf("invalid interval %q, expected daily, weekly, biweekly, monthly, quarterly, yearly or \"N days|weeks|months|years\"", Interval)
+}
+
+// Occurrence returns the date of the k-th occurrence (0 is the start).
+// Monthly and yearly dates keep the start's day of month, falling back to
+// the last day of shorter months, so a plan starting on the 31st lands on
+// the 30th in April and the 31st again in May.
+func (r Recurring) Occurrence(k int) (time.Time, error) {
+	start, err := ParseDay(r.Start)
+	if err != nil {
+		return time.Time{}, err
+	}
+	unit, n, err := ParseInterval(r.Interval)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch unit {
+	case "day":
+		return start.AddDate(0, 0, k*n), nil
+	case "week":
+		return start.AddDate(0, 0, 7*k*n), nil
+	case "year":
+		n *= 12
+	}
+	first := time.Date(start.Year(), start.Month()+time.Month(k*n), 1, 0, 0, 0, 0, time.Local)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := start.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1), nil
+}
+
+// Occurrences returns the dates of occurrences from index k onwards that
+// fall on or before until and on or before the end date
+func (r Recurring) Occurrences(k int, until time.Time) ([]time.Time, error) {
+	var end time.Time
+	if r.End != "" {
+		var err error
+		if end, err = ParseDay(r.End); err != nil {
+			return nil, err
+		}
+	}
+	var dates []time.Time
+	for ; ; k++ {
+		date, err := r.Occurrence(k)
+		if err != nil {
+			return nil, err
+		}
+		if date.After(until) || (!end.IsZero() && date.After(end)) {
+			return dates, nil
+		}
+		dates = append(dates, date)
+	}
+}
+
+// Next returns the next occurrence not yet in the ledger, or false once the
+// end date has passed
+func (r Recurring) Next() (time.Time, bool, error) {
+	date, err := r.Occurrence(r.Materialized)
+	if err != nil {
+		return date, false, err
+	}
+	if r.End != "" {
+		end, err := ParseDay(r.End)
+		if err != nil {
+			return date, false, err
+		}
+		if date.After(end) {
+			return date, false, nil
+		}
+	}
+	return date, true, nil
+}
+
+// SyncRecurring adds every occurrence that is due by today and not yet in
+// the ledger, returning the expenses it added
+func SyncRecurring() ([]Expense, error) {
+	recurring, err := LoadRecurring()
+	if err != nil || len(recurring) == 0 {
+		return nil, err
+	}
+	today, _ := ParseDay(time.Now().Format("2006-01-02"))
+
+	var added []Expense
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return nil, err
+	}
+	id := nextID(expenses)
+	for i, r := range recurring {
+		dates, err := r.Occurrences(r.Materialized, today)
+		if err != nil {
+			return nil, fmt.Errorf("recurring expense %d: %v", r.ID, err)
+		}
+		for _, date := range dates {
+			e := Expense{
+				ID:          id,
+				Description: r.Description,
+				Amount:      r.Amount,
+				Category:    r.Category,
+				Currency:    r.Currency,
+				Date:        date,
+				RecurringID: r.ID,
+			}
+			id++
+			expenses = append(expenses, e)
+			added = append(added, e)
+		}
+		recurring[i].Materialized += len(dates)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	// The ledger is written first: if saving the definitions then fails,
+	// the next sync would add the same occurrences again, so say so
+	if err := saveExpenses(expenses); err != nil {
+		return nil, err
+	}
+	if err := saveRecurring(recurring); err != nil {
+		return added, fmt.Errorf("%v (the %d expenses just added may be added again on the next sync)", err, len(added))
+	}
+	return added, nil
+}
+
+// Upcoming is one expected charge from a recurring definition
+type Upcoming struct {
+	Date      time.Time
+	Recurring Recurring
+}
+
+// UpcomingCharges lists occurrences after today and up to Days ahead,
+// in date order
+func UpcomingCharges(Days int) ([]Upcoming, error) {
+	recurring, err := LoadRecurring()
+	if err != nil {
+		return nil, err
+	}
+	today, _ := ParseDay(time.Now().Format("2006-01-02"))
+	until := today.AddDate(0, 0, Days)
+
+	var upcoming []Upcoming
+	for _, r := range recurring {
+		dates, err := r.Occurrences(r.Materialized, until)
+		if err != nil {
+			return nil, fmt.Errorf("recurring expense %d: %v", r.ID, err)
+		}
+		for _, date := range dates {
+			if date.After(today) {
+				upcoming = append(upcoming, Upcoming{Date: date, Recurring: r})
+			}
+		}
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool { return upcoming[i].Date.Before(upcoming[j].Date) })
+	return upcoming, nil
+}
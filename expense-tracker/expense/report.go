@@ -0,0 +1,163 @@
+package expense
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Groupings a report can total by
+const (
+	ByDay      = "day"
+	ByWeek     = "week"
+	ByMonth    = "month"
+	ByCategory = "category"
+)
+
+// Bucket is one line of a report: a day, week, month or category
+type Bucket struct {
+	Label    string
+	Total    float64
+	Previous float64 // same category in the previous period, for ByCategory
+}
+
+// Report totals expenses in a period, grouped one way, along with the
+// total for the period before it for trend comparison
+type Report struct {
+	Period        Period
+	Previous      Period
+	HasPrevious   bool
+	By            string
+	Currency      string
+	Buckets       []Bucket
+	Total         float64
+	PreviousTotal float64
+	Count         int
+}
+
+// Change is the relative change of the total against the previous period,
+// or false when there is nothing to compare with
+func (r Report) Change() (float64, bool) {
+	if !r.HasPrevious || r.PreviousTotal == 0 {
+		return 0, false
+	}
+	return (r.Total - r.PreviousTotal) / r.PreviousTotal, true
+}
+
+// BuildReport groups the expenses of a period By day, week, month or
+// category, converting into Currency (the base currency when empty). An
+// open period is narrowed to the days that have expenses. Time buckets
+// with no spending are included so charts show the gaps.
+func BuildReport(p Period, By string, Currency string) (Report, error) {
+	report := Report{By: By}
+	if By != ByDay && By != ByWeek && By != ByMonth && By != ByCategory {
+		return report, fmt.Errorf("unknown grouping %q, expected day, week, month or category", By)
+	}
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return report, fmt.Errorf("error loading expenses: %v", err)
+	}
+	converter, err := NewConverter()
+	if err != nil {
+		return report, err
+	}
+	if Currency == "" {
+		Currency = converter.Base
+	}
+	report.Currency = Currency
+
+	// Narrow open ends to the expenses actually recorded. The filter uses
+	// the period as given; the ends only move once every date is seen.
+	var first, last time.Time
+	for _, e := range expenses {
+		if e.Settlement || !p.Contains(e.Date) {
+			continue
+		}
+		day := time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), 0, 0, 0, 0, time.Local)
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if last.IsZero() || day.After(last) {
+			last = day
+		}
+	}
+	if p.From.IsZero() {
+		p.From = first
+	}
+	if p.To.IsZero() {
+		p.To = last
+	}
+	report.Period = p
+	if !p.Bounded() {
+		return report, nil
+	}
+	report.Previous, report.HasPrevious = p.Previous(), true
+
+	totals := map[string]float64{}
+	previous := map[string]float64{}
+	for _, e := range expenses {
+		if e.Settlement {
+			continue
+		}
+		inPeriod, inPrevious := p.Contains(e.Date), report.Previous.Contains(e.Date)
+		if !inPeriod && !inPrevious {
+			continue
+		}
+		amount, err := converter.Convert(e, Currency)
+		if err != nil {
+			return report, err
+		}
+		if inPrevious {
+			report.PreviousTotal += amount
+			if By == ByCategory {
+				previous[CategoryLabel(e.Category)] += amount
+			}
+			continue
+		}
+		report.Total += amount
+		report.Count++
+		if By == ByCategory {
+			totals[CategoryLabel(e.Category)] += amount
+		} else {
+			totals[bucketLabel(e.Date, By)] += amount
+		}
+	}
+
+	if By == ByCategory {
+		for label := range previous {
+			if _, ok := totals[label]; !ok {
+				totals[label] = 0
+			}
+		}
+		for label, total := range totals {
+			report.Buckets = append(report.Buckets, Bucket{Label: label, Total: total, Previous: previous[label]})
+		}
+		sort.Slice(report.Buckets, func(i, j int) bool {
+			if report.Buckets[i].Total != report.Buckets[j].Total {
+				return report.Buckets[i].Total > report.Buckets[j].Total
+			}
+			return report.Buckets[i].Label < report.Buckets[j].Label
+		})
+		return report, nil
+	}
+	for day := p.From; !day.After(p.To); day = day.AddDate(0, 0, 1) {
+		label := bucketLabel(day, By)
+		if n := len(report.Buckets); n > 0 && report.Buckets[n-1].Label == label {
+			continue
+		}
+		report.Buckets = append(report.Buckets, Bucket{Label: label, Total: totals[label]})
+	}
+	return report, nil
+}
+
+// bucketLabel names the day, ISO week or month a date falls in
+func bucketLabel(t time.Time, By string) string {
+	switch By {
+	case ByWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case ByMonth:
+		return t.Format(MonthLayout)
+	}
+	return t.Format("2006-01-02")
+}
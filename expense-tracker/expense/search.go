@@ -0,0 +1,185 @@
+package expense
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Search is a parsed search query: words to look for plus amount and date
+// predicates
+type Search struct {
+	Terms      []string
+	predicates []predicate
+}
+
+// predicate compares one field of an expense against a value
+type predicate struct {
+	field  string // amount or date
+	op     string // =, !=, <, <=, >, >=
+	amount float64
+	period Period
+}
+
+var searchOps = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseSearch reads query arguments. Plain words (or quoted phrases) must
+// all appear in the description, notes or category. Predicates look like
+// amount>50, amount<=100, date>=2026-10-01 or date=2026-10; a date may be a
+// day, a month (YYYY-MM) or a year, and compares against the whole range.
+func ParseSearch(args []string) (Search, error) {
+	var s Search
+	for _, arg := range args {
+		p, ok, err := parsePredicate(arg)
+		if err != nil {
+			return s, err
+		}
+		if ok {
+			s.predicates = append(s.predicates, p)
+			continue
+		}
+		if term := strings.ToLower(strings.TrimSpace(arg)); term != "" {
+			s.Terms = append(s.Terms, term)
+		}
+	}
+	return s, nil
+}
+
+func parsePredicate(arg string) (predicate, bool, error) {
+	lower := strings.ToLower(arg)
+	for _, field := range []string{"amount", "date"} {
+		if !strings.HasPrefix(lower, field) {
+			continue
+		}
+		rest := arg[len(field):]
+		for _, op := range searchOps {
+			if !strings.HasPrefix(rest, op) {
+				continue
+			}
+			value := strings.TrimSpace(rest[len(op):])
+			p := predicate{field: field, op: op}
+			var err error
+			if field == "amount" {
+				p.amount, err = strconv.ParseFloat(value, 64)
+				if err != nil {
+					return p, false, fmt.Errorf("invalid amount in %q", arg)
+				}
+			} else {
+				p.period, err = parseDateValue(value)
+				if err != nil {
+					return p, false, fmt.Errorf("invalid date in %q, expected YYYY-MM-DD, YYYY-MM or YYYY", arg)
+				}
+			}
+			return p, true, nil
+		}
+	}
+	return predicate{}, false, nil
+}
+
+// parseDateValue reads a day, month or year as the period it covers
+func parseDateValue(value string) (Period, error) {
+	if day, err := ParseDay(value); err == nil {
+		return Period{From: day, To: day}, nil
+	}
+	if month, err := ParseMonth(value); err == nil {
+		return month, nil
+	}
+	if len(value) == 4 {
+		if year, err := strconv.Atoi(value); err == nil {
+			return YearPeriod(year), nil
+		}
+	}
+	return Period{}, fmt.Errorf("invalid date %q", value)
+}
+
+// Match reports whether an expense satisfies every term and predicate
+func (s Search) Match(e Expense) bool {
+	return s.score(e) > 0
+}
+
+// score counts term hits, weighting the description highest; 0 means the
+// expense does not match
+func (s Search) score(e Expense) int {
+	for _, p := range s.predicates {
+		if !p.match(e) {
+			return 0
+		}
+	}
+	description := strings.ToLower(e.Description)
+	notes := strings.ToLower(e.Notes)
+	category := strings.ToLower(e.Category)
+	score := 1
+	for _, term := range s.Terms {
+		hits := 3*strings.Count(description, term) + strings.Count(notes, term) + 2*strings.Count(category, term)
+		if hits == 0 {
+			return 0
+		}
+		score += hits
+	}
+	return score
+}
+
+func (p predicate) match(e Expense) bool {
+	if p.field == "amount" {
+		a, b := toCents(e.Amount), toCents(p.amount)
+		switch p.op {
+		case "=":
+			return a == b
+		case "!=":
+			return a != b
+		case "<":
+			return a < b
+		case "<=":
+			return a <= b
+		case ">":
+			return a > b
+		}
+		return a >= b
+	}
+	in := p.period.Contains(e.Date)
+	before := !in && e.Date.Before(p.period.From)
+	switch p.op {
+	case "=":
+		return in
+	case "!=":
+		return !in
+	case "<":
+		return before
+	case "<=":
+		return in || before
+	case ">":
+		return !in && !before
+	}
+	return !before
+}
+
+// SearchExpenses returns the expenses matching a query, best matches first
+// and newest first among equals
+func SearchExpenses(s Search) ([]Expense, error) {
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return nil, fmt.Errorf("error loading expenses: %v", err)
+	}
+	type hit struct {
+		e     Expense
+		score int
+	}
+	var hits []hit
+	for _, e := range expenses {
+		if score := s.score(e); score > 0 {
+			hits = append(hits, hit{e, score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].e.Date.After(hits[j].e.Date)
+	})
+	result := make([]Expense, len(hits))
+	for i, h := range hits {
+		result[i] = h.e
+	}
+	return result, nil
+}
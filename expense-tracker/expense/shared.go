@@ -0,0 +1,391 @@
+package expense
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ways an expense can be split among participants
+const (
+	SplitEqual  = "equal"
+	SplitShares = "shares"
+	SplitExact  = "exact"
+)
+
+// Split is one participant's part of a shared expense
+type Split struct {
+	Person string  `json:"person"`
+	Share  float64 `json:"share,omitempty"` // weight, for shares splits
+	Amount float64 `json:"amount"`
+}
+
+// maxExactGroup is the largest number of people settled with the exact
+// (exponential) search for the fewest transfers
+const maxExactGroup = 16
+
+// NormalizePerson trims and lowercases a participant name
+func NormalizePerson(Person string) string {
+	return strings.ToLower(strings.TrimSpace(Person))
+}
+
+// ParseSplits works out each participant's amount. With lists people for
+// an equal split ("alice,bob"), people and weights for a shares split
+// ("alice=2,bob=1"), or people and amounts for an exact split
+// ("alice=30,bob=12.50"), which must add up to Amount.
+func ParseSplits(Mode string, With string, Amount float64) ([]Split, error) {
+	var splits []Split
+	seen := map[string]bool{}
+	for _, part := range strings.Split(With, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(part, "=")
+		s := Split{Person: NormalizePerson(name)}
+		if s.Person == "" {
+			return nil, fmt.Errorf("empty participant name in %q", With)
+		}
+		if seen[s.Person] {
+			return nil, fmt.Errorf("%s is listed twice", s.Person)
+		}
+		seen[s.Person] = true
+		switch Mode {
+		case SplitEqual:
+			if hasValue {
+				return nil, fmt.Errorf("equal splits take names only, got %q", part)
+			}
+			s.Share = 1
+		case SplitShares, SplitExact:
+			if !hasValue {
+				return nil, fmt.Errorf("%s splits need name=value, got %q", Mode, part)
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || v <= 0 {
+				return nil, fmt.Errorf("invalid value in %q, expected a positive number", part)
+			}
+			if Mode == SplitShares {
+				s.Share = v
+			} else {
+				s.Amount = v
+			}
+		default:
+			return nil, fmt.Errorf("unknown split %q, expected equal, shares or exact", Mode)
+		}
+		splits = append(splits, s)
+	}
+	if len(splits) == 0 {
+		return nil, fmt.Errorf("no participants given")
+	}
+	return resolveSplits(Mode, splits, Amount)
+}
+
+// resolveSplits fills in amounts in cents so they add up to exactly
+// Amount. Cents left over from rounding go to the first participants.
+func resolveSplits(Mode string, splits []Split, Amount float64) ([]Split, error) {
+	total := toCents(Amount)
+	if Mode == SplitExact {
+		var sum int64
+		for _, s := range splits {
+			sum += toCents(s.Amount)
+		}
+		if sum != total {
+			return nil, fmt.Errorf("exact split adds up to %.2f, not the amount %.2f", fromCents(sum), Amount)
+		}
+		return splits, nil
+	}
+	weight := func(s Split) float64 {
+		if Mode == SplitEqual {
+			return 1
+		}
+		return s.Share
+	}
+	weights := 0.0
+	for _, s := range splits {
+		weights += weight(s)
+	}
+	if weights <= 0 {
+		return nil, fmt.Errorf("%s split has no shares to divide by", Mode)
+	}
+	var assigned int64
+	cents := make([]int64, len(splits))
+	for i, s := range splits {
+		cents[i] = int64(math.Floor(float64(total) * weight(s) / weights))
+		assigned += cents[i]
+	}
+	for i := 0; assigned < total; i = (i + 1) % len(splits) {
+		cents[i]++
+		assigned++
+	}
+	result := make([]Split, len(splits))
+	for i, s := range splits {
+		result[i] = s
+		result[i].Amount = fromCents(cents[i])
+		if Mode == SplitEqual {
+			result[i].Share = 0
+		}
+	}
+	return result, nil
+}
+
+// ShareExpense records who paid an expense and how it is split. With an
+// empty Mode the current split is kept and recomputed for the expense's
+// amount, which is how an amount change carries over to equal and shares
+// splits.
+func ShareExpense(ID int, PaidBy string, Mode string, With string) error {
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return fmt.Errorf("error loading expenses: %v", err)
+	}
+	for i, e := range expenses {
+		if e.ID != ID {
+			continue
+		}
+		if err := applyShare(&e, PaidBy, Mode, With); err != nil {
+			return err
+		}
+		expenses[i] = e
+		return saveExpenses(expenses)
+	}
+	return fmt.Errorf("expense with ID %d not found", ID)
+}
+
+// applyShare sets the payer and split of e in memory, checking that the
+// split adds up to e's current amount. An exact split cannot follow an
+// amount change on its own, so it has to be given again.
+func applyShare(e *Expense, PaidBy string, Mode string, With string) error {
+	var err error
+	if PaidBy != "" {
+		e.PaidBy = NormalizePerson(PaidBy)
+	}
+	if Mode != "" {
+		e.Splits, err = ParseSplits(Mode, With, e.Amount)
+		e.SplitMode = Mode
+	} else if len(e.Splits) > 0 {
+		e.Splits, err = resolveSplits(e.SplitMode, e.Splits, e.Amount)
+		if err != nil && e.SplitMode == SplitExact {
+			err = fmt.Errorf("%v; give the new split with --split exact --with", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(e.Splits) > 0 && e.PaidBy == "" {
+		return fmt.Errorf("a split expense needs --paid-by")
+	}
+	return nil
+}
+
+// Balance is what a person is owed (positive) or owes (negative)
+type Balance struct {
+	Person string
+	Amount float64
+}
+
+// Transfer is a payment that settles part of the balances
+type Transfer struct {
+	From   string
+	To     string
+	Amount float64
+}
+
+// Balances nets out every shared expense and settlement in Currency (the
+// base currency when empty): whoever paid is owed the amount, and each
+// participant owes their part. People who are square are left out.
+func Balances(Currency string) ([]Balance, error) {
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return nil, fmt.Errorf("error loading expenses: %v", err)
+	}
+	converter, err := NewConverter()
+	if err != nil {
+		return nil, err
+	}
+	net := map[string]float64{}
+	for _, e := range expenses {
+		if e.PaidBy == "" || len(e.Splits) == 0 {
+			continue
+		}
+		// Convert each part at the expense's own rate
+		for _, s := range e.Splits {
+			amount, err := converter.ConvertAmount(s.Amount, converter.CurrencyOf(e), orBase(Currency, converter), e.Date)
+			if err != nil {
+				return nil, err
+			}
+			net[e.PaidBy] += amount
+			net[s.Person] -= amount
+		}
+	}
+	var balances []Balance
+	for person, amount := range net {
+		if toCents(amount) != 0 {
+			balances = append(balances, Balance{Person: person, Amount: fromCents(toCents(amount))})
+		}
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Amount != balances[j].Amount {
+			return balances[i].Amount > balances[j].Amount
+		}
+		return balances[i].Person < balances[j].Person
+	})
+	return balances, nil
+}
+
+func orBase(Currency string, c *Converter) string {
+	if Currency == "" {
+		return c.Base
+	}
+	return Currency
+}
+
+// SettleUp returns the fewest transfers that bring every balance to zero.
+// People are split into the largest number of groups whose balances cancel
+// out, since a group of n people needs n-1 transfers; for very large groups
+// a greedy match is used instead.
+func SettleUp(balances []Balance) []Transfer {
+	var people []string
+	var cents []int64
+	for _, b := range balances {
+		if c := toCents(b.Amount); c != 0 {
+			people = append(people, b.Person)
+			cents = append(cents, c)
+		}
+	}
+	var transfers []Transfer
+	for _, group := range zeroSumGroups(cents) {
+		transfers = append(transfers, greedyTransfers(people, cents, group)...)
+	}
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if transfers[i].From != transfers[j].From {
+			return transfers[i].From < transfers[j].From
+		}
+		return transfers[i].To < transfers[j].To
+	})
+	return transfers
+}
+
+// zeroSumGroups partitions indexes into as many groups summing to zero as
+// possible, by dynamic programming over subsets
+func zeroSumGroups(cents []int64) [][]int {
+	n := len(cents)
+	all := make([]int, n)
+	for i := range all {
+		all[i] = i
+	}
+	if n == 0 {
+		return nil
+	}
+	if n > maxExactGroup {
+		return [][]int{all}
+	}
+	size := 1 << n
+	sum := make([]int64, size)
+	best := make([]int, size)
+	last := make([]int, size) // element removed to reach the best sub-mask
+	for mask := 1; mask < size; mask++ {
+		low := 0
+		for mask&(1<<low) == 0 {
+			low++
+		}
+		sum[mask] = sum[mask&^(1<<low)] + cents[low]
+		best[mask] = -1
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 && best[mask&^(1<<i)] > best[mask] {
+				best[mask], last[mask] = best[mask&^(1<<i)], i
+			}
+		}
+		if sum[mask] == 0 {
+			best[mask]++
+		}
+	}
+	// Walk back from the full set; each zero-sum mask on the way closes a group
+	var groups [][]int
+	var group []int
+	for mask := size - 1; mask != 0; {
+		if sum[mask] == 0 && len(group) > 0 {
+			groups = append(groups, group)
+			group = nil
+		}
+		group = append(group, last[mask])
+		mask &^= 1 << last[mask]
+	}
+	return append(groups, group)
+}
+
+// greedyTransfers settles one zero-sum group by repeatedly paying the
+// largest creditor from the largest debtor
+func greedyTransfers(people []string, cents []int64, group []int) []Transfer {
+	left := map[int]int64{}
+	for _, i := range group {
+		left[i] = cents[i]
+	}
+	var transfers []Transfer
+	for {
+		debtor, creditor := -1, -1
+		for _, i := range group {
+			if left[i] < 0 && (debtor < 0 || left[i] < left[debtor]) {
+				debtor = i
+			}
+			if left[i] > 0 && (creditor < 0 || left[i] > left[creditor]) {
+				creditor = i
+			}
+		}
+		if debtor < 0 || creditor < 0 {
+			return transfers
+		}
+		amount := -left[debtor]
+		if left[creditor] < amount {
+			amount = left[creditor]
+		}
+		left[debtor] += amount
+		left[creditor] -= amount
+		transfers = append(transfers, Transfer{From: people[debtor], To: people[creditor], Amount: fromCents(amount)})
+	}
+}
+
+// RecordSettlements adds each transfer to the ledger as a settlement
+// entry in Currency (the base currency when empty). Settlements move
+// balances but are not counted as spending.
+func RecordSettlements(transfers []Transfer, Currency string) ([]Expense, error) {
+	Currency, err := resolveCurrency(Currency)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := LoadExpenses()
+	if err != nil {
+		return nil, fmt.Errorf("error loading expenses: %v", err)
+	}
+	id := nextID(expenses)
+	now := time.Now()
+	var added []Expense
+	for _, t := range transfers {
+		e := Expense{
+			ID:          id,
+			Description: fmt.Sprintf("Settlement: %s paid %s", t.From, t.To),
+			Amount:      t.Amount,
+			Currency:    Currency,
+			Date:        now,
+			PaidBy:      t.From,
+			Splits:      []Split{{Person: t.To, Amount: t.Amount}},
+			SplitMode:   SplitExact,
+			Settlement:  true,
+		}
+		id++
+		expenses = append(expenses, e)
+		added = append(added, e)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	return added, saveExpenses(expenses)
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
@@ -0,0 +1,84 @@
+package expense
+
+import (
+	"strings"
+	"testing"
+)
+
+// newSharedExpense adds the exact split from the update docs: carol paid
+// 42.50, alice owes 30 of it
+func newSharedExpense(t *testing.T, Mode string, With string) int {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	err, id := AddExpense("taxi", 42.50, "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := ShareExpense(id, "carol", Mode, With); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return id
+}
+
+func loadExpense(t *testing.T, ID int) Expense {
+	t.Helper()
+	expenses, err := LoadExpenses()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, e := range expenses {
+		if e.ID == ID {
+			return e
+		}
+	}
+	t.Fatalf("expense %d not found", ID)
+	return Expense{}
+}
+
+func splitTotal(e Expense) int64 {
+	var total int64
+	for _, s := range e.Splits {
+		total += toCents(s.Amount)
+	}
+	return total
+}
+
+func TestUpdateAmountOfExactSplitChangesNothing(t *testing.T) {
+	id := newSharedExpense(t, SplitExact, "alice=30,carol=12.50")
+
+	err := UpdateExpense(id, "", 50, "", "", "", "", "")
+	if err == nil || !strings.Contains(err.Error(), "--split exact --with") {
+		t.Fatalf("expected an error asking for the new split, got %v", err)
+	}
+	e := loadExpense(t, id)
+	if e.Amount != 42.50 {
+		t.Fatalf("expected the amount to stay 42.50, got %.2f", e.Amount)
+	}
+	if splitTotal(e) != toCents(e.Amount) {
+		t.Fatalf("expected the split to add up to %.2f, got %.2f", e.Amount, fromCents(splitTotal(e)))
+	}
+}
+
+func TestUpdateAmountWithNewExactSplit(t *testing.T) {
+	id := newSharedExpense(t, SplitExact, "alice=30,carol=12.50")
+
+	if err := UpdateExpense(id, "", 50, "", "", "", SplitExact, "alice=30,carol=20"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	e := loadExpense(t, id)
+	if e.Amount != 50 || splitTotal(e) != toCents(50) {
+		t.Fatalf("expected amount and split of 50, got %.2f split %.2f", e.Amount, fromCents(splitTotal(e)))
+	}
+}
+
+func TestUpdateAmountRecomputesEqualSplit(t *testing.T) {
+	id := newSharedExpense(t, SplitEqual, "alice,carol")
+
+	if err := UpdateExpense(id, "", 50, "", "", "", "", ""); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	e := loadExpense(t, id)
+	if len(e.Splits) != 2 || e.Splits[0].Amount != 25 || e.Splits[1].Amount != 25 {
+		t.Fatalf("expected 25 each, got %+v", e.Splits)
+	}
+}
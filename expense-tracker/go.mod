@@ -2,8 +2,9 @@ module expense-tracker
 
 go 1.24.4
 
+require github.com/spf13/cobra v1.9.1
+
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/cobra v1.9.1 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
 )
@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github-activity/events"
+)
+
+// outputFormats are the values accepted by --output
+var outputFormats = []string{"text", "table", "markdown", "json"}
+
+// jsonGroup is how a group of events is written with --output json
+type jsonGroup struct {
+	Type    string         `json:"type"`
+	Repo    string         `json:"repo"`
+	Actor   string         `json:"actor"`
+	From    time.Time      `json:"from"`
+	To      time.Time      `json:"to"`
+	Count   int            `json:"count"`
+	Summary string         `json:"summary"`
+	Events  []events.Event `json:"events"`
+}
+
+// writeGroups writes activity in one of the outputFormats. withActor adds
+// who did what, for digests of more than one account.
+func writeGroups(w io.Writer, groups []events.Group, format string, withActor bool) error {
+	switch format {
+	case "text":
+		if len(groups) == 0 {
+			fmt.Fprintln(w, "No activity found")
+		}
+		for _, g := range groups {
+			if withActor {
+				fmt.Fprintf(w, "%s: ", g.Actor)
+			}
+			fmt.Fprintln(w, g.Summary())
+		}
+	case "table":
+		writeTable(w, groups, withActor)
+	case "markdown":
+		writeMarkdown(w, groups, withActor)
+	case "json":
+		out := make([]jsonGroup, len(groups))
+		for i, g := range groups {
+			out[i] = newJSONGroup(g)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	default:
+		return fmt.Errorf("unknown output format %q (use %s)", format, strings.Join(outputFormats, ", "))
+	}
+	return nil
+}
+
+// writeJSONLines writes each group as one line of JSON, so a stream of
+// batches (as in --watch) stays parseable
+func writeJSONLines(w io.Writer, groups []events.Group) error {
+	enc := json.NewEncoder(w)
+	for _, g := range groups {
+		if err := enc.Encode(newJSONGroup(g)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newJSONGroup(g events.Group) jsonGroup {
+	return jsonGroup{
+		Type:    g.Type,
+		Repo:    g.Repo,
+		Actor:   g.Actor,
+		From:    g.Earliest(),
+		To:      g.Latest(),
+		Count:   len(g.Events),
+		Summary: g.Summary(),
+		Events:  g.Events,
+	}
+}
+
+func writeTable(w io.Writer, groups []events.Group, withActor bool) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if withActor {
+		fmt.Fprint(tw, "ACTOR\t")
+	}
+	fmt.Fprintln(tw, "TIME\tTYPE\tREPOSITORY\tACTIVITY")
+	for _, g := range groups {
+		if withActor {
+			fmt.Fprintf(tw, "%s\t", g.Actor)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			g.Latest().Local().Format("2006-01-02 15:04"),
+			strings.TrimSuffix(g.Type, "Event"),
+			g.Repo,
+			g.Summary())
+	}
+	tw.Flush()
+}
+
+// writeMarkdown writes a bullet list per day, in the order of the groups,
+// ready to paste into a status report
+func writeMarkdown(w io.Writer, groups []events.Group, withActor bool) {
+	if len(groups) == 0 {
+		fmt.Fprintln(w, "_No activity found._")
+		return
+	}
+	day := ""
+	for _, g := range groups {
+		d := g.Latest().Local().Format("Monday, 2 January 2006")
+		if d != day {
+			if day != "" {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "### %s\n\n", d)
+			day = d
+		}
+		repoLink := fmt.Sprintf("[%s](https://github.com/%s)", g.Repo, g.Repo)
+		actor := ""
+		if withActor {
+			actor = fmt.Sprintf("**%s**: ", escapeMarkdown(g.Actor))
+		}
+		fmt.Fprintf(w, "- %s%s\n", actor, strings.Replace(escapeMarkdown(g.Summary()), escapeMarkdown(g.Repo), repoLink, 1))
+	}
+}
+
+// escapeMarkdown escapes characters that would change the meaning of a
+// line of markdown
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", "&lt;").Replace(s)
+}
@@ -5,7 +5,13 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 	"github-activity/events"
 	"github.com/spf13/cobra"
 )
@@ -14,9 +20,9 @@ import (
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "github-activity <username>",
+	Use:   "github-activity <username|org:name|repo:owner/name>...",
 	Short: "A brief description of your application",
-	Args:  cobra.ExactArgs(1), // Ensure exactly one argument is provided
+	Args:  cobra.MinimumNArgs(1), // Ensure at least one target is provided
 	Long: `A longer description that spans multiple lines and likely contains
 examples and usage of using your application. For example:
 
@@ -26,9 +32,141 @@ to quickly create a Cobra application.`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	Run: func(cmd *cobra.Command, args []string) {
-		username := args[0]
-		events.FetchEvents(username)
-	 },
+		targets, err := parseTargets(args)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		client, err := newClient(cmd)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		filter, err := newFilter(cmd)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		group, _ := cmd.Flags().GetBool("group")
+		output, _ := cmd.Flags().GetString("output")
+		if !slices.Contains(outputFormats, output) {
+			fmt.Fprintf(os.Stderr, "Error: unknown output format %q (use %s)\n", output, strings.Join(outputFormats, ", "))
+			os.Exit(1)
+		}
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		if parallel < 1 {
+			fmt.Fprintln(os.Stderr, "Error: --parallel must be at least 1")
+			os.Exit(1)
+		}
+
+		// Name who did what once more than one account can show up
+		withActor := len(targets) > 1 || targets[0].Kind != events.TargetUser
+		show := func(list []events.Event) error {
+			groups := events.Ungrouped(list)
+			if group {
+				groups = events.GroupEvents(list)
+			}
+			return writeGroups(os.Stdout, groups, output, withActor)
+		}
+
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			interval, _ := cmd.Flags().GetDuration("interval")
+			if interval <= 0 {
+				fmt.Fprintln(os.Stderr, "Error: --interval must be positive")
+				os.Exit(1)
+			}
+			if output == "json" {
+				// One object per line, since batches keep coming
+				show = func(list []events.Event) error {
+					groups := events.Ungrouped(list)
+					if group {
+						groups = events.GroupEvents(list)
+					}
+					return writeJSONLines(os.Stdout, groups)
+				}
+			}
+			watchTargets(client, targets, parallel, interval, filter, show)
+			return
+		}
+
+		list, fetchErr := client.FetchAll(targets, parallel)
+		if err := show(filter.Apply(list)); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		if fetchErr != nil {
+			fmt.Fprintln(os.Stderr, "Error:", fetchErr)
+			os.Exit(1)
+		}
+	},
+}
+
+// parseTargets parses the command line targets, dropping duplicates
+func parseTargets(args []string) ([]events.Target, error) {
+	var targets []events.Target
+	for _, arg := range args {
+		t, err := events.ParseTarget(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(targets, t) {
+			targets = append(targets, t)
+		}
+	}
+	return targets, nil
+}
+
+// newClient builds an API client from the command line flags
+func newClient(cmd *cobra.Command) (*events.Client, error) {
+	token, _ := cmd.Flags().GetString("token")
+	baseURL, _ := cmd.Flags().GetString("api-url")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 1 || limit > events.MaxEvents {
+		return nil, fmt.Errorf("--limit must be between 1 and %d", events.MaxEvents)
+	}
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+	if ttl < 0 {
+		return nil, fmt.Errorf("--cache-ttl must not be negative")
+	}
+
+	client := events.NewClient(token)
+	client.BaseURL = baseURL
+	client.Limit = limit
+	if !noCache {
+		cache, err := events.NewCache(ttl)
+		if err != nil {
+			return nil, err
+		}
+		// Keep users of different API servers apart
+		if u, err := url.Parse(baseURL); err == nil && baseURL != events.DefaultBaseURL {
+			cache.Dir = filepath.Join(cache.Dir, strings.ReplaceAll(u.Host, ":", "_"))
+		}
+		client.Cache = cache
+	}
+	return client, nil
+}
+
+// newFilter builds an event filter from the command line flags
+func newFilter(cmd *cobra.Command) (events.Filter, error) {
+	var filter events.Filter
+	types, _ := cmd.Flags().GetStringSlice("type")
+	for _, t := range types {
+		eventType, err := events.ParseType(t)
+		if err != nil {
+			return filter, err
+		}
+		filter.Types = append(filter.Types, eventType)
+	}
+	filter.Repos, _ = cmd.Flags().GetStringSlice("repo")
+	if since, _ := cmd.Flags().GetString("since"); since != "" {
+		t, err := events.ParseSince(since, time.Now())
+		if err != nil {
+			return filter, err
+		}
+		filter.Since = t
+	}
+	return filter, nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -50,6 +188,19 @@ func init() {
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	rootCmd.PersistentFlags().String("token", "", "GitHub token (default $GITHUB_TOKEN or $GH_TOKEN)")
+	rootCmd.PersistentFlags().String("api-url", events.DefaultBaseURL, "GitHub API base URL")
+	rootCmd.PersistentFlags().IntP("limit", "l", events.MaxEvents, "Maximum number of events to fetch")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Always fetch from the API, bypassing the local cache")
+	rootCmd.PersistentFlags().Duration("cache-ttl", events.DefaultCacheTTL, "How long cached events are used before asking the API again")
+	rootCmd.Flags().StringSlice("type", nil, "Only show these event types, e.g. push,pr,issues")
+	rootCmd.Flags().StringSlice("repo", nil, "Only show events in these repositories (owner/name or name)")
+	rootCmd.Flags().String("since", "", "Only show events in this time window, e.g. 7d, 12h or 2025-06-01")
+	rootCmd.Flags().BoolP("group", "g", false, "Group consecutive events of the same kind in the same repository")
+	rootCmd.Flags().StringP("output", "o", "text", "Output format: text, table, markdown or json")
+	rootCmd.Flags().IntP("parallel", "p", events.DefaultParallelism, "Number of targets to fetch at once")
+	rootCmd.Flags().BoolP("watch", "w", false, "Keep polling and print only new events")
+	rootCmd.Flags().Duration("interval", time.Minute, "How often to poll in --watch mode")
 }
 
 
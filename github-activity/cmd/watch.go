@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	"github-activity/events"
+)
+
+// watchTargets polls the targets every interval until interrupted, and
+// prints the events that have not been seen before, oldest first so the
+// newest activity ends up at the bottom. The first poll only records what
+// is already there, so the existing backlog is not shown as new.
+func watchTargets(client *events.Client, targets []events.Target, parallel int, interval time.Duration, filter events.Filter, show func([]events.Event) error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Each poll must ask GitHub; conditional requests keep that cheap
+	if client.Cache != nil {
+		client.Cache.TTL = 0
+	}
+
+	seen := make(map[string]bool)
+	for baseline := true; ; baseline = false {
+		list, err := client.FetchAll(targets, parallel)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+
+		var unseen []events.Event
+		for _, e := range list {
+			if !seen[e.ID] {
+				seen[e.ID] = true
+				unseen = append(unseen, e)
+			}
+		}
+		if baseline {
+			fmt.Fprintf(os.Stderr, "Watching for new activity every %s, press Ctrl+C to stop\n", interval)
+			unseen = nil
+		}
+		unseen = filter.Apply(unseen)
+		slices.Reverse(unseen)
+		if len(unseen) > 0 {
+			if err := show(unseen); err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached events are used without asking the
+// API. GitHub asks clients not to poll events more often than this.
+const DefaultCacheTTL = time.Minute
+
+// Cache stores fetched events on disk along with the ETag of the response,
+// so later runs can send a conditional request. A 304 Not Modified answer
+// does not count against the rate limit.
+type Cache struct {
+	Dir string
+	// TTL is how long an entry is used without revalidating it; 0 always
+	// revalidates
+	TTL time.Duration
+}
+
+// cacheEntry is one cached events listing
+type cacheEntry struct {
+	ETag      string          `json:"etag"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Limit     int             `json:"limit"`
+	Events    json.RawMessage `json:"events"`
+}
+
+// DefaultCacheDir is the cache directory under the user's cache directory
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding cache directory: %w", err)
+	}
+	return filepath.Join(dir, "github-activity"), nil
+}
+
+// NewCache returns a cache in the default cache directory
+func NewCache(ttl time.Duration) (*Cache, error) {
+	dir, err := DefaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: dir, TTL: ttl}, nil
+}
+
+// path is the file an API path is cached in, e.g. users/octocat.json
+func (c *Cache) path(apiPath string) string {
+	key := strings.TrimSuffix(strings.Trim(apiPath, "/"), "/events")
+	key = strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(key)
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// load returns the entry for an API path, or nil if there is none. A
+// corrupt entry is treated as missing.
+func (c *Cache) load(apiPath string) *cacheEntry {
+	data, err := os.ReadFile(c.path(apiPath))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return nil
+	}
+	return &entry
+}
+
+func (c *Cache) save(apiPath string, entry *cacheEntry) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshalling cache entry: %w", err)
+	}
+	if err := os.WriteFile(c.path(apiPath), data, 0644); err != nil {
+		return fmt.Errorf("error writing cache: %w", err)
+	}
+	return nil
+}
+
+// fresh tells whether an entry can be used without revalidating it
+func (c *Cache) fresh(entry *cacheEntry) bool {
+	return c.TTL > 0 && time.Since(entry.FetchedAt) < c.TTL
+}
+
+// events decodes the events of an entry, trimmed to limit
+func (entry *cacheEntry) events(limit int) ([]Event, error) {
+	events, err := ParseEvents(entry.Events)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
@@ -2,82 +2,344 @@ package events
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-// Function to fetch events for a given username
-func FetchEvents(username string) {
+const (
+	// DefaultBaseURL is the GitHub REST API
+	DefaultBaseURL = "https://api.github.com"
+	// MaxEvents is how far back the events API goes
+	MaxEvents = 300
+	// perPage is the largest page size the events API accepts
+	perPage = 100
+	// maxRetryWait is the longest Retry-After the client waits out before
+	// giving up with a RateLimitError
+	maxRetryWait = 10 * time.Second
+)
+
+// TokenEnvVars are the environment variables a token is read from, in order
+var TokenEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+
+// Client fetches events from the GitHub API
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+	// Limit is the most events to fetch (at most MaxEvents)
+	Limit int
+	// RateLimit is the rate limit as of the last response
+	RateLimit RateLimit
+	// mu guards RateLimit when fetching concurrently
+	mu sync.Mutex
+	// Cache, if set, stores responses and revalidates them with ETags
+	Cache *Cache
+}
+
+// RateLimit is the state of the rate limit reported by the API
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimitError is returned when the API refuses a request because the
+// rate limit has been hit
+type RateLimitError struct {
+	RateLimit
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	msg := "GitHub API rate limit exceeded"
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", retry after %s", e.RetryAfter)
+	} else if !e.Reset.IsZero() {
+		msg += fmt.Sprintf(", resets at %s", e.Reset.Local().Format("15:04:05"))
+	}
+	if e.Limit > 0 && e.Limit <= 60 {
+		msg += " (authenticate with a token for a higher limit)"
+	}
+	return msg
+}
+
+// NotFoundError is returned when the user, organization or repository does
+// not exist
+type NotFoundError struct {
+	Path string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", e.Path)
+}
+
+// NewClient returns a client for the public GitHub API. An empty token is
+// taken from the environment (see TokenEnvVars).
+func NewClient(token string) *Client {
+	if token == "" {
+		token = TokenFromEnv()
+	}
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Limit:      MaxEvents,
+	}
+}
+
+// TokenFromEnv returns the first token set in TokenEnvVars
+func TokenFromEnv() string {
+	for _, name := range TokenEnvVars {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// FetchEvents fetches the public events of a user with a default client
+func FetchEvents(username string) ([]Event, error) {
+	return NewClient("").UserEvents(username)
+}
+
+// UserEvents fetches the public events of a user, following pagination up
+// to the client's limit. If the rate limit is hit part way, the events
+// fetched so far are returned along with a *RateLimitError.
+func (c *Client) UserEvents(username string) ([]Event, error) {
+	return c.Events(Target{Kind: TargetUser, Name: username})
+}
+
+// fetchEvents fetches all pages of an events endpoint, going through the
+// cache if the client has one
+func (c *Client) fetchEvents(path string) ([]Event, error) {
+	limit := c.Limit
+	if limit <= 0 || limit > MaxEvents {
+		limit = MaxEvents
+	}
+
+	var entry *cacheEntry
+	if c.Cache != nil {
+		entry = c.Cache.load(path)
+		if entry != nil && entry.Limit < limit {
+			entry = nil // cached with a smaller limit, too few events
+		}
+	}
+	if entry != nil && c.Cache.fresh(entry) {
+		return entry.events(limit)
+	}
+
+	etag := ""
+	if entry != nil {
+		etag = entry.ETag
+	}
+	first := fmt.Sprintf("%s%s?per_page=%d", strings.TrimRight(c.BaseURL, "/"), path, min(limit, perPage))
+	resp, err := c.get(first, etag)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			notFound.Path = path
+		}
+		if entry != nil {
+			// Stale events beat none, e.g. when rate limited
+			events, _ := entry.events(limit)
+			return events, err
+		}
+		return nil, err
+	}
+	if resp.notModified {
+		// Events are newest first, so an unchanged first page means
+		// nothing new happened
+		entry.FetchedAt = time.Now()
+		if err := c.Cache.save(path, entry); err != nil {
+			return nil, err
+		}
+		return entry.events(limit)
+	}
+
+	events, err := ParseEvents(resp.body)
+	if err != nil {
+		return nil, err
+	}
+	for next := nextLink(resp.link); next != "" && len(events) < limit; {
+		page, err := c.get(next, "")
+		if err != nil {
+			return events, err
+		}
+		pageEvents, err := ParseEvents(page.body)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, pageEvents...)
+		next = nextLink(page.link)
+	}
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	if c.Cache != nil && resp.etag != "" {
+		data, err := json.Marshal(events)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling events: %w", err)
+		}
+		entry := &cacheEntry{ETag: resp.etag, FetchedAt: time.Now(), Limit: limit, Events: data}
+		if err := c.Cache.save(path, entry); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
 
-	resp, err := http.Get(fmt.Sprintf("https://api.github.com/users/%s/events", username))
+// response is one page of an API response
+type response struct {
+	body        []byte
+	link        string
+	etag        string
+	notModified bool
+}
+
+// get requests one page, waiting out a short Retry-After once. A non-empty
+// etag makes the request conditional.
+func (c *Client) get(pageURL, etag string) (*response, error) {
+	resp, err := c.do(pageURL, etag)
+	var limited *RateLimitError
+	if errors.As(err, &limited) && limited.RetryAfter > 0 && limited.RetryAfter <= maxRetryWait {
+		time.Sleep(limited.RetryAfter)
+		return c.do(pageURL, etag)
+	}
+	return resp, err
+}
+
+func (c *Client) do(pageURL, etag string) (*response, error) {
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
 	if err != nil {
-		fmt.Println("Error fetching URL:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "github-activity")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching URL: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		fmt.Println("Error reading response body:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
+	c.mu.Lock()
+	c.RateLimit.update(resp.Header)
+	rateLimit := c.RateLimit
+	c.mu.Unlock()
 
-	var events []map[string]interface{}
-	err = json.Unmarshal(body, &events)
-	if err != nil {
-		fmt.Println("Error unmarshalling JSON:", err)
-		os.Exit(1)
-	}
-
-	// Gives a sample output of the first event
-	//b, _ := json.MarshalIndent(events[2], "", "  ")
-	//fmt.Println(string(b))
-
-	for _, event := range events {
-		eventType, _ := event["type"].(string)
-		repo := ""
-		if r, ok := event["repo"].(map[string]interface{}); ok {
-			repo, _ = r["name"].(string)
-		} else if r, ok := event["repo"].(map[string]string); ok {
-			repo = r["name"]
-		} else if r, ok := event["repo"].(map[string]any); ok {
-			if name, ok := r["name"].(string); ok {
-				repo = name
-			}
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return &response{body: body, link: resp.Header.Get("Link"), etag: resp.Header.Get("ETag")}, nil
+	case resp.StatusCode == http.StatusNotModified && etag != "":
+		return &response{etag: etag, notModified: true}, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &NotFoundError{}
+	case isRateLimited(resp):
+		return nil, &RateLimitError{
+			RateLimit:  rateLimit,
+			RetryAfter: retryAfter(resp.Header),
+			Message:    apiMessage(body),
+		}
+	}
+	if msg := apiMessage(body); msg != "" {
+		return nil, fmt.Errorf("GitHub API error: %s: %s", resp.Status, msg)
+	}
+	return nil, fmt.Errorf("GitHub API error: %s", resp.Status)
+}
+
+// update records the X-RateLimit-* headers of a response
+func (r *RateLimit) update(h http.Header) {
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		r.Limit = v
+	}
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		r.Remaining = v
+	}
+	if v, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		r.Reset = time.Unix(v, 0)
+	}
+}
+
+// isRateLimited tells a rate-limit response apart from other 403s
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+	return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+}
+
+func retryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t).Round(time.Second)
+	}
+	return 0
+}
+
+// apiMessage extracts the "message" field of an API error response
+func apiMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &apiErr) != nil {
+		return ""
+	}
+	return apiErr.Message
+}
+
+// nextLink returns the rel="next" URL of a Link header, if any
+func nextLink(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
 		}
-		switch eventType {
-		case "PushEvent":
-			payload, _ := event["payload"].(map[string]interface{})
-			commits := 0
-			if c, ok := payload["commits"].([]interface{}); ok {
-				commits = len(c)
+		target := strings.Trim(strings.TrimSpace(segments[0]), "<>")
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return target
 			}
-			fmt.Printf("Pushed %d commits to %s\n", commits, repo)
-		case "IssuesEvent":
-			payload, _ := event["payload"].(map[string]interface{})
-			action, _ := payload["action"].(string)
-			fmt.Printf("%s an issue in %s\n", capitalize(action), repo)
-		case "WatchEvent":
-			fmt.Printf("Starred %s\n", repo)
-		case "ForkEvent":
-			fmt.Printf("Forked %s\n", repo)
-		case "PullRequestEvent":
-			payload, _ := event["payload"].(map[string]interface{})
-			action, _ := payload["action"].(string)
-			fmt.Printf("%s a pull request in %s\n", capitalize(action), repo)
-		// Add more cases as needed for other event types
-		default:
-			// Uncomment to see all event types
-			// fmt.Printf("%s in %s\n", eventType, repo)
 		}
 	}
+	return ""
 }
 
 func capitalize(s string) string {
 	if len(s) == 0 {
 		return s
 	}
-	return string(s[0]-32) + s[1:]
+	return strings.ToUpper(s[:1]) + s[1:]
 }
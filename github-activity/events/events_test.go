@@ -0,0 +1,252 @@
+package events_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github-activity/events"
+)
+
+// eventsJSON renders n WatchEvents with IDs counting down from first, the
+// way the API lists events newest first
+func eventsJSON(first, n int) string {
+	var items []string
+	for i := 0; i < n; i++ {
+		items = append(items, fmt.Sprintf(`{"id":"%d","type":"WatchEvent","actor":{"login":"octocat"},"repo":{"name":"octo/repo"},"created_at":"2026-10-01T12:00:00Z","payload":{"action":"started"}}`, first-i))
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+// fakeGitHub serves a handler and counts the requests it gets
+type fakeGitHub struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newFakeGitHub(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) *fakeGitHub {
+	t.Helper()
+	f := &fakeGitHub{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests = append(f.requests, r)
+		f.mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeGitHub) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+func (f *fakeGitHub) client(limit int) *events.Client {
+	client := events.NewClient("test-token")
+	client.BaseURL = f.URL
+	client.Limit = limit
+	return client
+}
+
+func TestLinkHeaderPagination(t *testing.T) {
+	var server *fakeGitHub
+	server = newFakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "", "1":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/users/octocat/events?per_page=100&page=2>; rel="next", <%s/users/octocat/events?per_page=100&page=3>; rel="last"`, server.URL, server.URL))
+			fmt.Fprint(w, eventsJSON(300, 100))
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/users/octocat/events?per_page=100&page=1>; rel="prev", <%s/users/octocat/events?per_page=100&page=3>; rel="next"`, server.URL, server.URL))
+			fmt.Fprint(w, eventsJSON(200, 100))
+		case "3":
+			fmt.Fprint(w, eventsJSON(100, 50))
+		}
+	})
+
+	got, err := server.client(events.MaxEvents).UserEvents("octocat")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got) != 250 {
+		t.Fatalf("expected 250 events over 3 pages, got %d", len(got))
+	}
+	if got[249].ID != "51" {
+		t.Fatalf("expected last event 51, got %s", got[249].ID)
+	}
+	if server.count() != 3 {
+		t.Fatalf("expected 3 requests, got %d", server.count())
+	}
+	if auth := server.requests[0].Header.Get("Authorization"); auth != "Bearer test-token" {
+		t.Fatalf("expected bearer token, got %q", auth)
+	}
+}
+
+func TestPaginationStopsAtLimit(t *testing.T) {
+	var server *fakeGitHub
+	server = newFakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per_page"); got != "30" {
+			t.Errorf("expected per_page 30, got %q", got)
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/users/octocat/events?per_page=30&page=2>; rel="next"`, server.URL))
+		fmt.Fprint(w, eventsJSON(300, 30))
+	})
+
+	got, err := server.client(30).UserEvents("octocat")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got) != 30 || server.count() != 1 {
+		t.Fatalf("expected 30 events from 1 request, got %d from %d", len(got), server.count())
+	}
+}
+
+func TestNotModifiedUsesCache(t *testing.T) {
+	server := newFakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, eventsJSON(10, 3))
+	})
+	client := server.client(events.MaxEvents)
+	client.Cache = &events.Cache{Dir: t.TempDir()}
+
+	first, err := client.UserEvents("octocat")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, err := client.UserEvents("octocat")
+	if err != nil {
+		t.Fatalf("expected no error on 304, got %v", err)
+	}
+	if server.count() != 2 {
+		t.Fatalf("expected the cache to be revalidated, got %d requests", server.count())
+	}
+	if got := server.requests[1].Header.Get("If-None-Match"); got != `"v1"` {
+		t.Fatalf("expected If-None-Match \"v1\", got %q", got)
+	}
+	if len(second) != len(first) || second[0].ID != first[0].ID {
+		t.Fatalf("expected cached events %v, got %v", first, second)
+	}
+}
+
+func TestFreshCacheSkipsRequest(t *testing.T) {
+	server := newFakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, eventsJSON(10, 3))
+	})
+	client := server.client(events.MaxEvents)
+	client.Cache = &events.Cache{Dir: t.TempDir(), TTL: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.UserEvents("octocat"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if server.count() != 1 {
+		t.Fatalf("expected 1 request within the TTL, got %d", server.count())
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	server := newFakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset))
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"API rate limit exceeded"}`)
+	})
+
+	_, err := server.client(events.MaxEvents).UserEvents("octocat")
+	var limited *events.RateLimitError
+	if !errors.As(err, &limited) {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+	if limited.Limit != 60 || limited.Remaining != 0 || limited.Reset.Unix() != reset {
+		t.Fatalf("unexpected rate limit %+v", limited.RateLimit)
+	}
+	if limited.Message != "API rate limit exceeded" {
+		t.Fatalf("expected the API message, got %q", limited.Message)
+	}
+	if !strings.Contains(err.Error(), "authenticate with a token") {
+		t.Fatalf("expected a hint to authenticate, got %q", err)
+	}
+	if server.count() != 1 {
+		t.Fatalf("expected no retry without Retry-After, got %d requests", server.count())
+	}
+}
+
+func TestRateLimitRetriesShortRetryAfter(t *testing.T) {
+	attempts := 0
+	server := newFakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, eventsJSON(10, 2))
+	})
+
+	got, err := server.client(events.MaxEvents).UserEvents("octocat")
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if len(got) != 2 || server.count() != 2 {
+		t.Fatalf("expected 2 events after 2 requests, got %d after %d", len(got), server.count())
+	}
+}
+
+func TestRateLimitFallsBackToStaleCache(t *testing.T) {
+	limited := false
+	server := newFakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if limited {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, eventsJSON(10, 3))
+	})
+	client := server.client(events.MaxEvents)
+	client.Cache = &events.Cache{Dir: t.TempDir()}
+	if _, err := client.UserEvents("octocat"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	limited = true
+	got, err := client.UserEvents("octocat")
+	var rateErr *events.RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected the 3 cached events alongside the error, got %d", len(got))
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	server := newFakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := server.client(events.MaxEvents).UserEvents("nobody")
+	var notFound *events.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected a NotFoundError, got %v", err)
+	}
+	if notFound.Path != "/users/nobody/events" {
+		t.Fatalf("expected the events path, got %q", notFound.Path)
+	}
+}
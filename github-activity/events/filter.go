@@ -0,0 +1,128 @@
+package events
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter selects events by type, repository and age. Empty fields match
+// everything.
+type Filter struct {
+	Types []string // canonical event types, see ParseType
+	Repos []string // owner/name, or just name for any owner
+	Since time.Time
+}
+
+// typeAliases are short names accepted by ParseType besides the event type
+// itself
+var typeAliases = map[string]string{
+	"pr":      "PullRequestEvent",
+	"review":  "PullRequestReviewEvent",
+	"issue":   "IssuesEvent",
+	"comment": "IssueCommentEvent",
+	"star":    "WatchEvent",
+	"wiki":    "GollumEvent",
+	"branch":  "CreateEvent",
+	"tag":     "CreateEvent",
+}
+
+// ParseType turns a user-supplied event type like "push", "PushEvent",
+// "pull-request" or "pr" into the event type used by the API
+func ParseType(name string) (string, error) {
+	key := typeKey(name)
+	if t, ok := typeAliases[key]; ok {
+		return t, nil
+	}
+	for t := range payloadTypes {
+		if typeKey(t) == key {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown event type %q (known types: %s)", name, strings.Join(EventTypes(), ", "))
+}
+
+// typeKey normalizes an event type for comparison: PushEvent, push and
+// push-event all become "push"
+func typeKey(name string) string {
+	key := strings.ToLower(strings.NewReplacer("-", "", "_", "", " ", "").Replace(name))
+	if key != "event" {
+		key = strings.TrimSuffix(key, "event")
+	}
+	return key
+}
+
+// ParseSince parses a time window like 7d, 2w, 12h or 30m, or a date like
+// 2025-06-01, into the time it starts at
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if len(s) > 1 {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n >= 0 {
+			switch s[len(s)-1] {
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time window %q (use e.g. 7d, 2w, 12h or 2025-06-01)", s)
+}
+
+// Match tells whether an event passes the filter
+func (f Filter) Match(e Event) bool {
+	if !f.Since.IsZero() && e.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if len(f.Types) > 0 && !contains(f.Types, e.Type) {
+		return false
+	}
+	if len(f.Repos) > 0 {
+		matched := false
+		for _, repo := range f.Repos {
+			if matchRepo(repo, e.Repo.Name) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the events that pass the filter, in their original order
+func (f Filter) Apply(events []Event) []Event {
+	var matched []Event
+	for _, e := range events {
+		if f.Match(e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// matchRepo compares a repository filter with a full owner/name
+func matchRepo(filter, repo string) bool {
+	if strings.Contains(filter, "/") {
+		return strings.EqualFold(filter, repo)
+	}
+	_, name, _ := strings.Cut(repo, "/")
+	return strings.EqualFold(filter, name)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Group is a run of consecutive events of the same type, by the same
+// actor, in the same repository
+type Group struct {
+	Type   string
+	Repo   string
+	Actor  string
+	Events []Event // in the order they were given, newest first for the API
+}
+
+// GroupEvents merges consecutive events of the same type, actor and
+// repository into groups, so twelve pushes in a row become one line
+func GroupEvents(events []Event) []Group {
+	var groups []Group
+	for _, e := range events {
+		if n := len(groups); n > 0 {
+			last := &groups[n-1]
+			if last.Type == e.Type && last.Repo == e.Repo.Name && last.Actor == e.Actor.Login {
+				last.Events = append(last.Events, e)
+				continue
+			}
+		}
+		groups = append(groups, singleGroup(e))
+	}
+	return groups
+}
+
+// Ungrouped puts every event in a group of its own
+func Ungrouped(events []Event) []Group {
+	groups := make([]Group, len(events))
+	for i, e := range events {
+		groups[i] = singleGroup(e)
+	}
+	return groups
+}
+
+func singleGroup(e Event) Group {
+	return Group{Type: e.Type, Repo: e.Repo.Name, Actor: e.Actor.Login, Events: []Event{e}}
+}
+
+// Latest is the time of the most recent event in the group
+func (g Group) Latest() time.Time {
+	var latest time.Time
+	for _, e := range g.Events {
+		if e.CreatedAt.After(latest) {
+			latest = e.CreatedAt
+		}
+	}
+	return latest
+}
+
+// Earliest is the time of the oldest event in the group
+func (g Group) Earliest() time.Time {
+	earliest := g.Latest()
+	for _, e := range g.Events {
+		if e.CreatedAt.Before(earliest) {
+			earliest = e.CreatedAt
+		}
+	}
+	return earliest
+}
+
+// Summary renders the group as one human-readable line
+func (g Group) Summary() string {
+	n := len(g.Events)
+	if n == 1 {
+		return g.Events[0].Summary()
+	}
+
+	switch g.Type {
+	case "PushEvent":
+		commits := 0
+		for _, e := range g.Events {
+			if push, ok := e.Payload.(*PushEvent); ok {
+				commits += push.CommitCount()
+			}
+		}
+		if commits == 0 {
+			return fmt.Sprintf("Pushed %d times to %s", n, g.Repo)
+		}
+		return fmt.Sprintf("Pushed %s to %s across %d pushes", plural(commits, "commit"), g.Repo, n)
+	case "IssueCommentEvent", "CommitCommentEvent", "PullRequestReviewCommentEvent":
+		return fmt.Sprintf("Left %s in %s", plural(n, "comment"), g.Repo)
+	case "PullRequestReviewEvent":
+		return fmt.Sprintf("Reviewed pull requests %d times in %s", n, g.Repo)
+	case "CreateEvent":
+		counts := map[string]int{}
+		for _, e := range g.Events {
+			if create, ok := e.Payload.(*CreateEvent); ok {
+				if create.RefType == "repository" || create.Ref == "" {
+					counts["repository"]++
+				} else {
+					counts[create.RefType]++
+				}
+			}
+		}
+		return fmt.Sprintf("Created %s in %s", refCounts(counts), g.Repo)
+	case "DeleteEvent":
+		counts := map[string]int{}
+		for _, e := range g.Events {
+			if del, ok := e.Payload.(*DeleteEvent); ok {
+				counts[del.RefType]++
+			}
+		}
+		return fmt.Sprintf("Deleted %s in %s", refCounts(counts), g.Repo)
+	case "GollumEvent":
+		pages := 0
+		for _, e := range g.Events {
+			if gollum, ok := e.Payload.(*GollumEvent); ok {
+				pages += len(gollum.Pages)
+			}
+		}
+		return fmt.Sprintf("Updated %s in %s", plural(pages, "wiki page"), g.Repo)
+	}
+	return fmt.Sprintf("%s (and %d more)", g.Events[0].Summary(), n-1)
+}
+
+// refCounts lists counts of created or deleted refs by type, e.g. "the
+// repository, 2 branches and 1 tag"
+func refCounts(counts map[string]int) string {
+	var parts []string
+	if counts["repository"] > 0 {
+		parts = append(parts, "the repository")
+	}
+	if n := counts["branch"]; n > 0 {
+		parts = append(parts, plural(n, "branch", "branches"))
+	}
+	if n := counts["tag"]; n > 0 {
+		parts = append(parts, plural(n, "tag"))
+	}
+	other := 0
+	for refType, n := range counts {
+		if refType != "repository" && refType != "branch" && refType != "tag" {
+			other += n
+		}
+	}
+	if other > 0 {
+		parts = append(parts, plural(other, "ref"))
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
+
+// plural formats a count with the singular or plural form of a noun. The
+// plural defaults to the singular with an "s".
+func plural(n int, singular string, pluralForm ...string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", singular)
+	}
+	if len(pluralForm) > 0 {
+		return fmt.Sprintf("%d %s", n, pluralForm[0])
+	}
+	return fmt.Sprintf("%d %ss", n, singular)
+}
@@ -0,0 +1,44 @@
+package events_test
+
+import (
+	"testing"
+
+	"github-activity/events"
+)
+
+func createEvent(refType, ref string) events.Event {
+	return events.Event{
+		Type:    "CreateEvent",
+		Repo:    events.Repo{Name: "octo/repo"},
+		Payload: &events.CreateEvent{RefType: refType, Ref: ref},
+	}
+}
+
+func TestCreateGroupSummary(t *testing.T) {
+	tests := []struct {
+		events []events.Event
+		want   string
+	}{
+		{
+			[]events.Event{createEvent("branch", "main"), createEvent("repository", "")},
+			"Created the repository and 1 branch in octo/repo",
+		},
+		{
+			[]events.Event{createEvent("tag", "v1.1"), createEvent("branch", "fix"), createEvent("tag", "v1.0"), createEvent("branch", "main")},
+			"Created 2 branches and 2 tags in octo/repo",
+		},
+		{
+			[]events.Event{createEvent("tag", "v2"), createEvent("branch", "dev"), createEvent("repository", "")},
+			"Created the repository, 1 branch and 1 tag in octo/repo",
+		},
+	}
+	for _, tt := range tests {
+		groups := events.GroupEvents(tt.events)
+		if len(groups) != 1 {
+			t.Fatalf("expected 1 group, got %d", len(groups))
+		}
+		if got := groups[0].Summary(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Event is one entry from the GitHub events API. The payload is decoded
+// into the concrete type for the event (PushEvent, IssuesEvent, ...) so
+// every event can be rendered, including types added after this code was
+// written, which fall back to UnknownEvent.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Actor     Actor     `json:"actor"`
+	Repo      Repo      `json:"repo"`
+	Org       *Actor    `json:"org,omitempty"`
+	Public    bool      `json:"public"`
+	CreatedAt time.Time `json:"created_at"`
+	Payload   Payload   `json:"-"`
+	// RawPayload keeps the payload as received, for output formats that
+	// want everything
+	RawPayload json.RawMessage `json:"payload"`
+}
+
+// Actor is the user (or organization) an event belongs to
+type Actor struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// Repo is the repository an event happened in
+type Repo struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"` // owner/name
+}
+
+// Payload is the type-specific part of an event
+type Payload interface {
+	// Summary renders the event as one human-readable line
+	Summary(repo string) string
+}
+
+// payloadTypes maps event types to a constructor for their payload
+var payloadTypes = map[string]func() Payload{
+	"CommitCommentEvent":            func() Payload { return &CommitCommentEvent{} },
+	"CreateEvent":                   func() Payload { return &CreateEvent{} },
+	"DeleteEvent":                   func() Payload { return &DeleteEvent{} },
+	"DiscussionEvent":               func() Payload { return &DiscussionEvent{} },
+	"ForkEvent":                     func() Payload { return &ForkEvent{} },
+	"GollumEvent":                   func() Payload { return &GollumEvent{} },
+	"IssueCommentEvent":             func() Payload { return &IssueCommentEvent{} },
+	"IssuesEvent":                   func() Payload { return &IssuesEvent{} },
+	"MemberEvent":                   func() Payload { return &MemberEvent{} },
+	"PublicEvent":                   func() Payload { return &PublicEvent{} },
+	"PullRequestEvent":              func() Payload { return &PullRequestEvent{} },
+	"PullRequestReviewEvent":        func() Payload { return &PullRequestReviewEvent{} },
+	"PullRequestReviewCommentEvent": func() Payload { return &PullRequestReviewCommentEvent{} },
+	"PullRequestReviewThreadEvent":  func() Payload { return &PullRequestReviewThreadEvent{} },
+	"PushEvent":                     func() Payload { return &PushEvent{} },
+	"ReleaseEvent":                  func() Payload { return &ReleaseEvent{} },
+	"SponsorshipEvent":              func() Payload { return &SponsorshipEvent{} },
+	"WatchEvent":                    func() Payload { return &WatchEvent{} },
+}
+
+// EventTypes lists the event types with a dedicated payload, sorted
+func EventTypes() []string {
+	types := make([]string, 0, len(payloadTypes))
+	for t := range payloadTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// UnmarshalJSON decodes an event and its payload. A payload that does not
+// have the expected shape is kept as an UnknownEvent rather than failing
+// the whole response.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type plain Event
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	e.Payload = &UnknownEvent{Type: e.Type}
+	newPayload, ok := payloadTypes[e.Type]
+	if !ok || len(e.RawPayload) == 0 {
+		return nil
+	}
+	payload := newPayload()
+	if err := json.Unmarshal(e.RawPayload, payload); err == nil {
+		e.Payload = payload
+	}
+	return nil
+}
+
+// Summary renders the event as one human-readable line
+func (e Event) Summary() string {
+	if e.Payload == nil {
+		return (&UnknownEvent{Type: e.Type}).Summary(e.Repo.Name)
+	}
+	return e.Payload.Summary(e.Repo.Name)
+}
+
+// ParseEvents decodes a page of events from the API
+func ParseEvents(data []byte) ([]Event, error) {
+	var events []Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("error unmarshalling events: %w", err)
+	}
+	return events, nil
+}
+
+// UnknownEvent stands in for event types without a dedicated payload
+type UnknownEvent struct {
+	Type string
+}
+
+func (p *UnknownEvent) Summary(repo string) string {
+	name := strings.TrimSuffix(p.Type, "Event")
+	if name == "" {
+		name = "Unknown activity"
+	}
+	return fmt.Sprintf("%s in %s", splitWords(name), repo)
+}
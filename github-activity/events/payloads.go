@@ -0,0 +1,388 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// User is a GitHub account referenced from a payload
+type User struct {
+	Login string `json:"login"`
+}
+
+// Issue is the issue an IssuesEvent or IssueCommentEvent is about
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	// PullRequest is set when the "issue" is really a pull request
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request,omitempty"`
+}
+
+// PullRequest is the pull request a pull request event is about
+type PullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+	HTMLURL string `json:"html_url"`
+}
+
+// Comment is a comment on an issue, pull request or commit
+type Comment struct {
+	Body     string `json:"body"`
+	HTMLURL  string `json:"html_url"`
+	CommitID string `json:"commit_id"`
+}
+
+// Commit is one commit of a push
+type Commit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+	Distinct bool `json:"distinct"`
+}
+
+// CommitCommentEvent: a commit was commented on
+type CommitCommentEvent struct {
+	Action  string  `json:"action"`
+	Comment Comment `json:"comment"`
+}
+
+func (p *CommitCommentEvent) Summary(repo string) string {
+	return fmt.Sprintf("Commented on commit %s in %s", shortSHA(p.Comment.CommitID), repo)
+}
+
+// CreateEvent: a repository, branch or tag was created
+type CreateEvent struct {
+	Ref          string `json:"ref"`
+	RefType      string `json:"ref_type"` // repository, branch or tag
+	MasterBranch string `json:"master_branch"`
+	Description  string `json:"description"`
+}
+
+func (p *CreateEvent) Summary(repo string) string {
+	if p.RefType == "repository" || p.Ref == "" {
+		return fmt.Sprintf("Created repository %s", repo)
+	}
+	return fmt.Sprintf("Created %s %s in %s", p.RefType, p.Ref, repo)
+}
+
+// DeleteEvent: a branch or tag was deleted
+type DeleteEvent struct {
+	Ref     string `json:"ref"`
+	RefType string `json:"ref_type"` // branch or tag
+}
+
+func (p *DeleteEvent) Summary(repo string) string {
+	return fmt.Sprintf("Deleted %s %s in %s", p.RefType, p.Ref, repo)
+}
+
+// DiscussionEvent: a discussion was created or changed
+type DiscussionEvent struct {
+	Action     string `json:"action"`
+	Discussion struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"discussion"`
+}
+
+func (p *DiscussionEvent) Summary(repo string) string {
+	return fmt.Sprintf("%s discussion #%d in %s%s", verb(p.Action), p.Discussion.Number, repo, titled(p.Discussion.Title))
+}
+
+// ForkEvent: a repository was forked
+type ForkEvent struct {
+	Forkee struct {
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"forkee"`
+}
+
+func (p *ForkEvent) Summary(repo string) string {
+	if p.Forkee.FullName == "" {
+		return fmt.Sprintf("Forked %s", repo)
+	}
+	return fmt.Sprintf("Forked %s to %s", repo, p.Forkee.FullName)
+}
+
+// GollumEvent: wiki pages were created or updated
+type GollumEvent struct {
+	Pages []struct {
+		PageName string `json:"page_name"`
+		Title    string `json:"title"`
+		Action   string `json:"action"` // created or edited
+		HTMLURL  string `json:"html_url"`
+	} `json:"pages"`
+}
+
+func (p *GollumEvent) Summary(repo string) string {
+	if len(p.Pages) == 1 {
+		page := p.Pages[0]
+		action := "Updated"
+		if page.Action == "created" {
+			action = "Created"
+		}
+		return fmt.Sprintf("%s wiki page %q in %s", action, page.Title, repo)
+	}
+	return fmt.Sprintf("Updated %d wiki pages in %s", len(p.Pages), repo)
+}
+
+// IssueCommentEvent: an issue or pull request was commented on
+type IssueCommentEvent struct {
+	Action  string  `json:"action"`
+	Issue   Issue   `json:"issue"`
+	Comment Comment `json:"comment"`
+}
+
+func (p *IssueCommentEvent) Summary(repo string) string {
+	kind := "issue"
+	if p.Issue.PullRequest != nil {
+		kind = "pull request"
+	}
+	action := "Commented on"
+	switch p.Action {
+	case "edited":
+		action = "Edited a comment on"
+	case "deleted":
+		action = "Deleted a comment on"
+	}
+	return fmt.Sprintf("%s %s #%d in %s%s", action, kind, p.Issue.Number, repo, titled(p.Issue.Title))
+}
+
+// IssuesEvent: an issue was opened, closed, labeled and so on
+type IssuesEvent struct {
+	Action   string `json:"action"`
+	Issue    Issue  `json:"issue"`
+	Assignee *User  `json:"assignee,omitempty"`
+	Label    *struct {
+		Name string `json:"name"`
+	} `json:"label,omitempty"`
+}
+
+func (p *IssuesEvent) Summary(repo string) string {
+	detail := ""
+	switch {
+	case p.Label != nil && (p.Action == "labeled" || p.Action == "unlabeled"):
+		detail = fmt.Sprintf(" (%s)", p.Label.Name)
+	case p.Assignee != nil && (p.Action == "assigned" || p.Action == "unassigned"):
+		detail = fmt.Sprintf(" (%s)", p.Assignee.Login)
+	}
+	return fmt.Sprintf("%s issue #%d in %s%s%s", verb(p.Action), p.Issue.Number, repo, detail, titled(p.Issue.Title))
+}
+
+// MemberEvent: a collaborator was added to a repository
+type MemberEvent struct {
+	Action string `json:"action"`
+	Member User   `json:"member"`
+}
+
+func (p *MemberEvent) Summary(repo string) string {
+	switch p.Action {
+	case "removed":
+		return fmt.Sprintf("Removed %s as a collaborator from %s", p.Member.Login, repo)
+	case "edited":
+		return fmt.Sprintf("Changed %s's permissions in %s", p.Member.Login, repo)
+	}
+	return fmt.Sprintf("Added %s as a collaborator to %s", p.Member.Login, repo)
+}
+
+// PublicEvent: a private repository was made public
+type PublicEvent struct{}
+
+func (p *PublicEvent) Summary(repo string) string {
+	return fmt.Sprintf("Made %s public", repo)
+}
+
+// PullRequestEvent: a pull request was opened, closed, merged and so on
+type PullRequestEvent struct {
+	Action      string      `json:"action"`
+	Number      int         `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+}
+
+func (p *PullRequestEvent) Summary(repo string) string {
+	action := verb(p.Action)
+	if p.Action == "closed" && p.PullRequest.Merged {
+		action = "Merged"
+	}
+	return fmt.Sprintf("%s pull request #%d in %s%s", action, p.number(), repo, titled(p.PullRequest.Title))
+}
+
+func (p *PullRequestEvent) number() int {
+	if p.Number != 0 {
+		return p.Number
+	}
+	return p.PullRequest.Number
+}
+
+// PullRequestReviewEvent: a pull request was reviewed
+type PullRequestReviewEvent struct {
+	Action string `json:"action"`
+	Review struct {
+		State   string `json:"state"` // approved, changes_requested, commented
+		HTMLURL string `json:"html_url"`
+	} `json:"review"`
+	PullRequest PullRequest `json:"pull_request"`
+}
+
+func (p *PullRequestReviewEvent) Summary(repo string) string {
+	action := "Reviewed"
+	switch strings.ToLower(p.Review.State) {
+	case "approved":
+		action = "Approved"
+	case "changes_requested":
+		action = "Requested changes on"
+	case "dismissed":
+		action = "Dismissed a review of"
+	}
+	return fmt.Sprintf("%s pull request #%d in %s%s", action, p.PullRequest.Number, repo, titled(p.PullRequest.Title))
+}
+
+// PullRequestReviewCommentEvent: a line of a pull request diff was
+// commented on
+type PullRequestReviewCommentEvent struct {
+	Action      string      `json:"action"`
+	Comment     Comment     `json:"comment"`
+	PullRequest PullRequest `json:"pull_request"`
+}
+
+func (p *PullRequestReviewCommentEvent) Summary(repo string) string {
+	return fmt.Sprintf("Commented on the code of pull request #%d in %s%s", p.PullRequest.Number, repo, titled(p.PullRequest.Title))
+}
+
+// PullRequestReviewThreadEvent: a review thread was resolved or reopened
+type PullRequestReviewThreadEvent struct {
+	Action      string      `json:"action"` // resolved or unresolved
+	PullRequest PullRequest `json:"pull_request"`
+}
+
+func (p *PullRequestReviewThreadEvent) Summary(repo string) string {
+	action := "Resolved"
+	if p.Action == "unresolved" {
+		action = "Reopened"
+	}
+	return fmt.Sprintf("%s a review thread on pull request #%d in %s", action, p.PullRequest.Number, repo)
+}
+
+// PushEvent: commits were pushed to a branch
+type PushEvent struct {
+	PushID       int64    `json:"push_id"`
+	Size         int      `json:"size"`
+	DistinctSize int      `json:"distinct_size"`
+	Ref          string   `json:"ref"`
+	Head         string   `json:"head"`
+	Before       string   `json:"before"`
+	Commits      []Commit `json:"commits"`
+}
+
+// CommitCount is the number of commits pushed. The API may leave out the
+// commit list, in which case it is unknown (0).
+func (p *PushEvent) CommitCount() int {
+	if p.Size > 0 {
+		return p.Size
+	}
+	return len(p.Commits)
+}
+
+// Branch is the branch pushed to, without refs/heads/
+func (p *PushEvent) Branch() string {
+	return strings.TrimPrefix(p.Ref, "refs/heads/")
+}
+
+func (p *PushEvent) Summary(repo string) string {
+	n := p.CommitCount()
+	switch {
+	case n == 0:
+		return fmt.Sprintf("Pushed to %s in %s", p.Branch(), repo)
+	case n == 1:
+		return fmt.Sprintf("Pushed 1 commit to %s", repo)
+	}
+	return fmt.Sprintf("Pushed %d commits to %s", n, repo)
+}
+
+// ReleaseEvent: a release was published
+type ReleaseEvent struct {
+	Action  string `json:"action"`
+	Release struct {
+		TagName    string `json:"tag_name"`
+		Name       string `json:"name"`
+		HTMLURL    string `json:"html_url"`
+		Draft      bool   `json:"draft"`
+		Prerelease bool   `json:"prerelease"`
+	} `json:"release"`
+}
+
+func (p *ReleaseEvent) Summary(repo string) string {
+	kind := "release"
+	if p.Release.Prerelease {
+		kind = "pre-release"
+	}
+	name := p.Release.TagName
+	if name == "" {
+		name = p.Release.Name
+	}
+	return fmt.Sprintf("%s %s %s in %s", verb(p.Action), kind, name, repo)
+}
+
+// SponsorshipEvent: a sponsorship was started or changed
+type SponsorshipEvent struct {
+	Action        string `json:"action"`
+	EffectiveDate string `json:"effective_date"`
+}
+
+func (p *SponsorshipEvent) Summary(repo string) string {
+	return fmt.Sprintf("%s a sponsorship", verb(p.Action))
+}
+
+// WatchEvent: a repository was starred
+type WatchEvent struct {
+	Action string `json:"action"` // started
+}
+
+func (p *WatchEvent) Summary(repo string) string {
+	return fmt.Sprintf("Starred %s", repo)
+}
+
+// verb turns an API action like review_requested into "Review requested"
+func verb(action string) string {
+	if action == "" {
+		return "Updated"
+	}
+	return capitalize(strings.ReplaceAll(action, "_", " "))
+}
+
+// titled appends a title after a colon, if there is one
+func titled(title string) string {
+	if title == "" {
+		return ""
+	}
+	return ": " + title
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// splitWords turns PullRequestReview into "Pull request review"
+func splitWords(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteRune(' ')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultParallelism is how many targets FetchAll fetches at once
+const DefaultParallelism = 4
+
+// Target kinds
+const (
+	TargetUser = "user"
+	TargetOrg  = "org"
+	TargetRepo = "repo"
+)
+
+// Target is something with an events feed: a user, an organization or a
+// repository
+type Target struct {
+	Kind string
+	Name string // login, organization or owner/name
+}
+
+// ParseTarget parses a command line target: a username, org:<name> or
+// repo:<owner/name>
+func ParseTarget(arg string) (Target, error) {
+	kind, name, found := strings.Cut(arg, ":")
+	if !found {
+		kind, name = TargetUser, arg
+	}
+	name = strings.TrimSpace(name)
+	switch kind {
+	case TargetUser, TargetOrg:
+		if name == "" || strings.Contains(name, "/") {
+			return Target{}, fmt.Errorf("invalid %s %q", kind, name)
+		}
+	case TargetRepo:
+		owner, repo, ok := strings.Cut(name, "/")
+		if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return Target{}, fmt.Errorf("invalid repository %q (use repo:owner/name)", name)
+		}
+	default:
+		return Target{}, fmt.Errorf("unknown target %q (use a username, org:<name> or repo:<owner/name>)", arg)
+	}
+	return Target{Kind: kind, Name: name}, nil
+}
+
+// String formats the target the way ParseTarget reads it
+func (t Target) String() string {
+	if t.Kind == TargetUser {
+		return t.Name
+	}
+	return t.Kind + ":" + t.Name
+}
+
+// path is the API path of the target's events feed
+func (t Target) path() string {
+	switch t.Kind {
+	case TargetOrg:
+		return fmt.Sprintf("/orgs/%s/events", url.PathEscape(t.Name))
+	case TargetRepo:
+		owner, repo, _ := strings.Cut(t.Name, "/")
+		return fmt.Sprintf("/repos/%s/%s/events", url.PathEscape(owner), url.PathEscape(repo))
+	}
+	return fmt.Sprintf("/users/%s/events", url.PathEscape(t.Name))
+}
+
+// Events fetches the events of a target, following pagination up to the
+// client's limit
+func (c *Client) Events(t Target) ([]Event, error) {
+	return c.fetchEvents(t.path())
+}
+
+// FetchAll fetches several targets, at most parallelism at a time, and
+// merges them into one list, newest first. An event that shows up for
+// more than one target (a user's push to an org repository, say) is only
+// listed once. Failed targets are reported together in the error, after
+// the events of the others.
+func (c *Client) FetchAll(targets []Target, parallelism int) ([]Event, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	results := make([][]Event, len(targets))
+	errs := make([]error, len(targets))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for i, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = c.Events(t)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("%s: %w", t, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	var merged []Event
+	for _, events := range results {
+		for _, e := range events {
+			if e.ID != "" && seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			merged = append(merged, e)
+		}
+	}
+	SortNewestFirst(merged)
+	return merged, errors.Join(errs...)
+}
+
+// SortNewestFirst sorts events by time, newest first, the order the API
+// uses
+func SortNewestFirst(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.After(events[j].CreatedAt)
+	})
+}
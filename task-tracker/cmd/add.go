@@ -11,6 +11,17 @@ import (
 	"os"
 )
 
+// Optional task fields shared by add and update
+var (
+	taskPriority string
+	taskDue      string
+	taskTags     []string
+	taskProject  string
+	taskParent   int
+	taskDeps     string
+	taskEvery    string
+)
+
 // addCmd represents the add command
 var addCmd = &cobra.Command{
 	Use:   "add",
@@ -18,17 +29,48 @@ var addCmd = &cobra.Command{
 	Args:  cobra.MinimumNArgs(1), // Ensure at least one argument is provided
 	Long: `Adds a new task to existing list. For example:
 
-./task-tracker add "Buy groceries"`,
+./task-tracker add "Buy groceries"
+./task-tracker add "Fix login bug" --priority high --due 2026-11-01 --tag backend,auth --project web
+./task-tracker add "Write migration" --parent 4 --depends-on 2,3
+./task-tracker add "Water plants" --every "weekly on mon,thu"
+./task-tracker add "Pay rent" --every "monthly on 1"
+./task-tracker add "Backup" --every "0 9 * * 5"`,
 	Run: func(cmd *cobra.Command, args []string) {
-		task, err := tasks.AddTask(args[0])
+		deps, err := tasks.ParseIDs(taskDeps)
+		if err != nil {
+			fmt.Println("Invalid dependencies:", err)
+			os.Exit(1)
+		}
+		task, err := tasks.AddTask(openStore(), tasks.Task{
+			Description: args[0],
+			Priority:    taskPriority,
+			Due:         taskDue,
+			Tags:        taskTags,
+			Project:     taskProject,
+			ParentID:    taskParent,
+			DependsOn:   deps,
+			Recurrence:  taskEvery,
+		})
 		if err != nil {
 			fmt.Println("Error adding task:", err)
    			os.Exit(1)
   		}
-		fmt.Println("Task added successfully:", task.ID)
+		printResult(fmt.Sprint("Task added successfully: ", task.ID), task)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(addCmd)
+	addTaskFieldFlags(addCmd)
+}
+
+// addTaskFieldFlags registers the optional task field flags on cmd
+func addTaskFieldFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&taskPriority, "priority", "p", "", "Priority (low|medium|high|urgent)")
+	cmd.Flags().StringVar(&taskDue, "due", "", "Due date (YYYY-MM-DD, today or tomorrow)")
+	cmd.Flags().StringSliceVar(&taskTags, "tag", nil, "Tags, comma separated or repeated")
+	cmd.Flags().StringVar(&taskProject, "project", "", "Project name")
+	cmd.Flags().IntVar(&taskParent, "parent", 0, "Make this a subtask of the given task ID (0 for none)")
+	cmd.Flags().StringVar(&taskDeps, "depends-on", "", "Comma separated IDs of tasks that must be done first")
+	cmd.Flags().StringVar(&taskEvery, "every", "", `Repeat rule, e.g. "weekly on mon", "every 2 weeks", "monthly on 15" or a cron expression`)
 }
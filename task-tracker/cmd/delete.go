@@ -27,12 +27,12 @@ var deleteCmd = &cobra.Command{
             fmt.Println("Invalid ID:", args[0])
             os.Exit(1)
         }
-		err = tasks.DeleteTask(id)
+		task, err := tasks.DeleteTask(openStore(), id)
 		if err != nil {
             fmt.Println("Error deleting task:", err)
             os.Exit(1)
         }
-		fmt.Println("Task deleted successfully:", id)
+		printResult(fmt.Sprint("Task deleted successfully: ", id), task)
 	},
 }
 
@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 Pranav <pranavppatil767@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"task-tracker/tasks"
+
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history [id]",
+	Short: "Show the change history",
+	Args:  cobra.MaximumNArgs(1),
+	Long: `Show the audit trail for one task, including every status transition, or
+the whole journal when no ID is given. For example:
+
+./task-tracker history 3
+./task-tracker history`,
+	Run: func(cmd *cobra.Command, args []string) {
+		journal, err := openStore().Journal()
+		if err != nil {
+			fmt.Println("Error reading journal:", err)
+			os.Exit(1)
+		}
+		if len(args) == 0 {
+			printJournal(journal)
+			return
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Println("Invalid ID:", args[0])
+			os.Exit(1)
+		}
+		entries := tasks.EntriesFor(journal, id)
+		if len(entries) == 0 {
+			fmt.Println("No history for task:", id)
+			os.Exit(1)
+		}
+		printTaskHistory(entries, id, opLabels(journal))
+	},
+}
+
+// printTaskHistory prints one row per field change of the given task
+func printTaskHistory(entries []tasks.Entry, id int, labels map[int]string) {
+	var rows [][]string
+	for _, e := range entries {
+		for _, c := range e.Changes {
+			if c.ID != id {
+				continue
+			}
+			for _, line := range c.Describe() {
+				rows = append(rows, []string{strconv.Itoa(e.Seq), e.Time, labels[e.Seq], line})
+			}
+		}
+	}
+	render(os.Stdout, entries, []string{"Seq", "Time", "Op", "Change"}, rows)
+}
+
+// printJournal prints one row per journal entry
+func printJournal(journal []tasks.Entry) {
+	labels := opLabels(journal)
+	rows := make([][]string, 0, len(journal))
+	for _, e := range journal {
+		ids := make([]string, len(e.Changes))
+		for i, c := range e.Changes {
+			ids[i] = strconv.Itoa(c.ID)
+		}
+		rows = append(rows, []string{strconv.Itoa(e.Seq), e.Time, labels[e.Seq], strings.Join(ids, ",")})
+	}
+	if journal == nil {
+		journal = []tasks.Entry{}
+	}
+	render(os.Stdout, journal, []string{"Seq", "Time", "Op", "Tasks"}, rows)
+}
+
+// printEntryResult reports an undo or redo: the message and what changed
+// for the table format, the entry itself for the others
+func printEntryResult(message string, e tasks.Entry) {
+	if outputFormat == outputTable {
+		fmt.Println(message)
+		for _, c := range e.Changes {
+			for _, line := range c.Describe() {
+				fmt.Printf("  task %d: %s\n", c.ID, line)
+			}
+		}
+		return
+	}
+	printJournal([]tasks.Entry{e})
+}
+
+// opLabels names each entry, spelling out what undo and redo entries reversed
+func opLabels(journal []tasks.Entry) map[int]string {
+	labels := make(map[int]string, len(journal))
+	for _, e := range journal {
+		switch {
+		case e.Undoes != 0:
+			labels[e.Seq] = fmt.Sprintf("undo #%d %s", e.Undoes, labels[e.Undoes])
+		case e.Redoes != 0:
+			labels[e.Seq] = fmt.Sprintf("redo #%d %s", e.Redoes, labels[e.Redoes])
+		default:
+			labels[e.Seq] = e.Op
+		}
+	}
+	return labels
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
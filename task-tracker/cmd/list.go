@@ -5,92 +5,106 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"task-tracker/tasks"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	// sort keys for list, e.g. --sort priority,-due
+	listSort []string
+	listTree bool
+)
+
 // listCmd represents the list command
 var listCmd = &cobra.Command{
-	Use:   "list",
+	Use:   "list [filter...]",
 	Short: "List all tasks",
-	Long: `List all tasks. For example:
+	Long: `List all tasks, optionally narrowed by a filter expression. For example:
+
+./task-tracker list
+./task-tracker list status:todo tag:backend due<2026-11-01 priority>=high
+./task-tracker list project:web -tag:docs --sort priority,-due
+./task-tracker list status:blocked
+./task-tracker list --tree
 
-./task-tracker list`,
+Filter terms are ANDed together. Each term is field, operator and value:
+  fields     id, status, priority, due, created, updated, tag, project, parent,
+             series, recurrence, description
+  operators  : (equals), !=, <, <=, >, >= (comparisons work on id, priority and dates)
+  values     "none" matches an empty field; dates accept YYYY-MM-DD, today or tomorrow
+A leading "-" negates a term and a bare word searches the description.
+Tasks whose dependencies are unfinished show (and filter) as BLOCKED.
+
+Sort keys are the same fields (except tag and recurrence); prefix a key with "-" for descending order.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		task, err := tasks.LoadTasks()
-		if err != nil {
-			fmt.Println("Error loading tasks:", err)
-		}
-		for _, t := range task {
-			fmt.Printf("ID: %d, Description: %s, Status: %s, Created At: %s, Updated At: %s\n", t.ID, t.Description, t.Status, t.CreatedAt, t.UpdatedAt)
-		}
+		listTasks(args)
 	},
 }
 
 var listDoneCmd = &cobra.Command{
-	Use:   "done",
+	Use:   "done [filter...]",
 	Short: "List all tasks that are marked as done",
 	Long: `A Subcommand for list which lists out all tasks marked as done For example:
 
 ./task-tracker list done`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("list done called")
-		task, err := tasks.LoadTasks()
-		if err != nil {
-			fmt.Println("Error loading tasks:", err)
-		}
-		for _, t := range task {
-			if t.Status == "DONE" {
-				fmt.Printf("ID: %d, Description: %s, Status: %s, Created At: %s, Updated At: %s\n", t.ID, t.Description, t.Status, t.CreatedAt, t.UpdatedAt)
-			}
-		}
+		listTasks(append([]string{"status:done"}, args...))
 	},
 }
 
 var listToDoCmd = &cobra.Command{
-	Use:   "todo",
+	Use:   "todo [filter...]",
 	Short: "List all tasks that are marked as To-Do",
 	Long: `A Subcommand for list which lists out all tasks marked as To-Do For example:
 
 ./task-tracker list todo`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("list todo called")
-		task, err := tasks.LoadTasks()
-		if err != nil {
-			fmt.Println("Error loading tasks:", err)
-		}
-		for _, t := range task {
-			if t.Status == "TO-DO" {
-				fmt.Printf("ID: %d, Description: %s, Status: %s, Created At: %s, Updated At: %s\n", t.ID, t.Description, t.Status, t.CreatedAt, t.UpdatedAt)
-			}
-		}
+		listTasks(append([]string{"status:todo"}, args...))
 	},
 }
 
 var listInProgressCmd = &cobra.Command{
-	Use:   "in-progress",
+	Use:   "in-progress [filter...]",
 	Short: "List all tasks that are marked as In-Progress",
 	Long: `A Subcommand for list which lists out all tasks marked as IN-PROGRESS. For example:
 
 ./task-tracker list in-progress`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("list in-progress called")
-		task, err := tasks.LoadTasks()
-		if err != nil {
-			fmt.Println("Error loading tasks:", err)
-		}
-		for _, t := range task {
-			if t.Status == "IN-PROGRESS" {
-				fmt.Printf("ID: %d, Description: %s, Status: %s, Created At: %s, Updated At: %s\n", t.ID, t.Description, t.Status, t.CreatedAt, t.UpdatedAt)
-			}
-		}
+		listTasks(append([]string{"status:in-progress"}, args...))
 	},
 }
 
+// listTasks prints the tasks matching the filter, ordered by listSort
+func listTasks(filter []string) {
+	query, err := tasks.ParseQuery(filter...)
+	if err != nil {
+		fmt.Println("Invalid filter:", err)
+		os.Exit(1)
+	}
+	all, err := tasks.LoadTasks(openStore())
+	if err != nil {
+		fmt.Println("Error loading tasks:", err)
+		os.Exit(1)
+	}
+	matched := query.Filter(tasks.ResolveBlocked(all))
+	if err := tasks.SortTasks(matched, listSort); err != nil {
+		fmt.Println("Invalid sort:", err)
+		os.Exit(1)
+	}
+	if listTree {
+		printTree(tasks.BuildTree(matched))
+		return
+	}
+	printTasks(matched)
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.AddCommand(listDoneCmd)
 	listCmd.AddCommand(listToDoCmd)
 	listCmd.AddCommand(listInProgressCmd)
+	listCmd.PersistentFlags().StringSliceVar(&listSort, "sort", nil, "Sort keys, e.g. priority,-due (default id)")
+	listCmd.PersistentFlags().BoolVar(&listTree, "tree", false, "Show subtasks nested under their parents")
 }
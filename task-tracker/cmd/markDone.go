@@ -12,29 +12,38 @@ import (
 	"task-tracker/tasks"
 )
 
+var markDoneForce bool
+
 // markDoneCmd represents the markDone command
 var markDoneCmd = &cobra.Command{
 	Use:   "mark-done",
 	Short: "Mark a task as done by ID",
 	Args:  cobra.MinimumNArgs(1), // Ensure at least one argument is provided
-	Long: `Mark a task as DONE by ID For example:
+	Long: `Mark a task as DONE by ID. A task with open subtasks or unfinished
+dependencies is refused unless --force is given. Completing a recurring task
+creates its next occurrence with a new ID and due date. For example:
 
-./task-tracker mark-done 1`,
+./task-tracker mark-done 1
+./task-tracker mark-done 1 --force`,
 	Run: func(cmd *cobra.Command, args []string) {
 		id,err := strconv.Atoi(args[0])
 		if err != nil {
             fmt.Println("Invalid ID:", args[0])
             os.Exit(1)
         }
-		err = tasks.MarkTaskDone(id)
+		task, next, err := tasks.MarkTaskDone(openStore(), id, markDoneForce)
 		if err != nil {
             fmt.Println("Error Updating task:", err)
             os.Exit(1)
         }
-		fmt.Println("Task Updated successfully:", id)
+		printResult(fmt.Sprint("Task Updated successfully: ", id), task)
+		if next != nil {
+			printResult(fmt.Sprintf("Next occurrence created: %d (due %s)", next.ID, next.Due), *next)
+		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(markDoneCmd)
+	markDoneCmd.Flags().BoolVarP(&markDoneForce, "force", "f", false, "Complete even with open subtasks or unfinished dependencies")
 }
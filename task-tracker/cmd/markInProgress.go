@@ -25,12 +25,12 @@ var markInProgressCmd = &cobra.Command{
 			fmt.Println("Invalid ID:", args[0])
 			os.Exit(1)
 		}
-		err = tasks.MarkTaskInProgress(id)
+		task, err := tasks.MarkTaskInProgress(openStore(), id)
 		if err != nil {
 			fmt.Println("Error Updating task:", err)
 			os.Exit(1)
 		}
-		fmt.Println("Task Updated successfully:", id)
+		printResult(fmt.Sprint("Task Updated successfully: ", id), task)
 	},
 }
 
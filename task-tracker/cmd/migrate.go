@@ -0,0 +1,51 @@
+/*
+Copyright © 2025 Pranav <pranavppatil767@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"task-tracker/tasks"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateFrom  string
+	migrateTo    string
+	migrateForce bool
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy tasks from tasks.json into the SQLite store",
+	Long: `Copy every task from a JSON file into an SQLite database, keeping IDs. For example:
+
+./task-tracker migrate
+./task-tracker migrate --from old-tasks.json --to tasks.db --force`,
+	Run: func(cmd *cobra.Command, args []string) {
+		src := tasks.NewJSONStore(migrateFrom)
+		dst, err := tasks.OpenSQLiteStore(migrateTo)
+		if err != nil {
+			fmt.Println("Error opening SQLite store:", err)
+			os.Exit(1)
+		}
+		defer dst.Close()
+
+		n, err := tasks.CopyTasks(src, dst, migrateForce)
+		if err != nil {
+			fmt.Println("Error migrating tasks:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Migrated %d tasks from %s to %s\n", n, migrateFrom, migrateTo)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().StringVar(&migrateFrom, "from", tasks.DefaultJSONPath, "JSON file to read tasks from")
+	migrateCmd.Flags().StringVar(&migrateTo, "to", tasks.DefaultSQLitePath, "SQLite database to write tasks to")
+	migrateCmd.Flags().BoolVar(&migrateForce, "force", false, "Replace tasks already in the database")
+}
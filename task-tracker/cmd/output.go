@@ -0,0 +1,204 @@
+/*
+Copyright © 2025 Pranav <pranavppatil767@gmail.com>
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"task-tracker/tasks"
+	"text/tabwriter"
+)
+
+// Output formats for --output
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputCSV      = "csv"
+	outputMarkdown = "markdown"
+)
+
+var outputFormat string
+
+// validateOutput normalises --output, accepting "md" for markdown
+func validateOutput() error {
+	outputFormat = strings.ToLower(outputFormat)
+	switch outputFormat {
+	case outputTable, outputJSON, outputCSV, outputMarkdown:
+		return nil
+	case "md":
+		outputFormat = outputMarkdown
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q (expected table, json, csv or markdown)", outputFormat)
+}
+
+var taskHeaders = []string{"ID", "Status", "Priority", "Due", "Repeat", "Project", "Tags", "Parent", "Depends On", "Description", "Created At", "Updated At"}
+
+// printTasks writes tasks in the selected output format
+func printTasks(list []tasks.Task) {
+	rows := make([][]string, 0, len(list))
+	for _, t := range list {
+		rows = append(rows, taskRow(t))
+	}
+	render(os.Stdout, list, taskHeaders, rows)
+}
+
+// printTask echoes a single task; JSON output is an object, not an array
+func printTask(t tasks.Task) {
+	render(os.Stdout, t, taskHeaders, [][]string{taskRow(t)})
+}
+
+// printResult prints a confirmation message for the table format and the
+// affected task for every format
+func printResult(message string, t tasks.Task) {
+	if outputFormat == outputTable {
+		fmt.Println(message)
+	}
+	printTask(t)
+}
+
+func taskRow(t tasks.Task) []string {
+	return []string{
+		strconv.Itoa(t.ID),
+		t.Status,
+		t.Priority,
+		t.Due,
+		t.Recurrence,
+		t.Project,
+		strings.Join(t.Tags, ","),
+		formatID(t.ParentID),
+		joinInts(t.DependsOn),
+		t.Description,
+		t.CreatedAt,
+		t.UpdatedAt,
+	}
+}
+
+// render writes value as JSON, or headers and rows as a table, CSV or
+// Markdown depending on --output
+func render(w io.Writer, value any, headers []string, rows [][]string) {
+	switch outputFormat {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(value); err != nil {
+			fmt.Println("Error encoding JSON:", err)
+			os.Exit(1)
+		}
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(headers)
+		cw.WriteAll(rows)
+		if err := cw.Error(); err != nil {
+			fmt.Println("Error writing CSV:", err)
+			os.Exit(1)
+		}
+	case outputMarkdown:
+		fmt.Fprintln(w, markdownRow(headers))
+		sep := make([]string, len(headers))
+		for i := range sep {
+			sep[i] = "---"
+		}
+		fmt.Fprintln(w, markdownRow(sep))
+		for _, row := range rows {
+			fmt.Fprintln(w, markdownRow(row))
+		}
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		upper := make([]string, len(headers))
+		for i, h := range headers {
+			upper[i] = strings.ToUpper(h)
+		}
+		fmt.Fprintln(tw, strings.Join(upper, "\t"))
+		for _, row := range rows {
+			cells := make([]string, len(row))
+			for i, c := range row {
+				// Keep the columns aligned when a value is empty or multi-line
+				if c == "" {
+					c = "-"
+				}
+				cells[i] = strings.ReplaceAll(c, "\n", " ")
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		tw.Flush()
+	}
+}
+
+// printTree prints the task hierarchy. JSON nests subtasks, Markdown uses
+// a nested list and the table format draws the tree.
+func printTree(roots []*tasks.Node) {
+	switch outputFormat {
+	case outputJSON:
+		if roots == nil {
+			roots = []*tasks.Node{}
+		}
+		render(os.Stdout, roots, nil, nil)
+	case outputCSV:
+		fmt.Println("--tree is not supported with csv output")
+		os.Exit(1)
+	case outputMarkdown:
+		var walk func(nodes []*tasks.Node, depth int)
+		walk = func(nodes []*tasks.Node, depth int) {
+			for _, n := range nodes {
+				fmt.Printf("%s- %s\n", strings.Repeat("  ", depth), treeLabel(n.Task))
+				walk(n.Subtasks, depth+1)
+			}
+		}
+		walk(roots, 0)
+	default:
+		var walk func(nodes []*tasks.Node, prefix string)
+		walk = func(nodes []*tasks.Node, prefix string) {
+			for i, n := range nodes {
+				branch, next := "├── ", "│   "
+				if i == len(nodes)-1 {
+					branch, next = "└── ", "    "
+				}
+				fmt.Println(prefix + branch + treeLabel(n.Task))
+				walk(n.Subtasks, prefix+next)
+			}
+		}
+		for _, n := range roots {
+			fmt.Println(treeLabel(n.Task))
+			walk(n.Subtasks, "")
+		}
+	}
+}
+
+func treeLabel(t tasks.Task) string {
+	label := fmt.Sprintf("#%d [%s] %s", t.ID, t.Status, t.Description)
+	if len(t.DependsOn) > 0 {
+		label += " (depends on " + joinInts(t.DependsOn) + ")"
+	}
+	return label
+}
+
+func formatID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+func joinInts(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		c = strings.ReplaceAll(c, "|", `\|`)
+		escaped[i] = strings.ReplaceAll(c, "\n", "<br>")
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
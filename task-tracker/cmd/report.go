@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Pranav <pranavppatil767@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"task-tracker/tasks"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFrom string
+	reportTo   string
+	reportBy   string
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report [filter...]",
+	Short: "Report tracked time",
+	Long: `Total the time tracked with start/stop over a date range, grouped by
+task, tag, day or week. The range defaults to the last 7 days and --to is
+inclusive. An optional filter narrows the tasks, as in list. For example:
+
+./task-tracker report
+./task-tracker report --by tag --from 2026-10-01 --to 2026-10-31
+./task-tracker report --by week project:web -o markdown`,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, err := tasks.ParseDay(reportFrom)
+		if err != nil {
+			fmt.Println("Invalid --from:", err)
+			os.Exit(1)
+		}
+		to, err := tasks.ParseDay(reportTo)
+		if err != nil {
+			fmt.Println("Invalid --to:", err)
+			os.Exit(1)
+		}
+		query, err := tasks.ParseQuery(args...)
+		if err != nil {
+			fmt.Println("Invalid filter:", err)
+			os.Exit(1)
+		}
+		all, err := tasks.LoadTasks(openStore())
+		if err != nil {
+			fmt.Println("Error loading tasks:", err)
+			os.Exit(1)
+		}
+
+		// --to names the last day included in the report
+		rows, total, err := tasks.Report(query.Filter(tasks.ResolveBlocked(all)), from, to.AddDate(0, 0, 1), reportBy)
+		if err != nil {
+			fmt.Println("Error building report:", err)
+			os.Exit(1)
+		}
+
+		table := make([][]string, 0, len(rows)+1)
+		for _, r := range rows {
+			table = append(table, []string{r.Key, formatDuration(r.Duration), fmt.Sprintf("%.2f", r.Duration.Hours())})
+		}
+		if outputFormat == outputTable || outputFormat == outputMarkdown {
+			table = append(table, []string{"Total", formatDuration(total), fmt.Sprintf("%.2f", total.Hours())})
+		}
+		render(os.Stdout, struct {
+			From         string            `json:"from"`
+			To           string            `json:"to"`
+			By           string            `json:"by"`
+			Rows         []tasks.ReportRow `json:"rows"`
+			TotalSeconds int64             `json:"total_seconds"`
+		}{reportFrom, reportTo, reportBy, rows, int64(total.Seconds())},
+			[]string{strings.ToUpper(reportBy[:1]) + reportBy[1:], "Time", "Hours"}, table)
+	},
+}
+
+// formatDuration prints a duration as hours and minutes, e.g. 2h05m
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	today := time.Now()
+	reportCmd.Flags().StringVar(&reportFrom, "from", today.AddDate(0, 0, -6).Format(tasks.DateLayout), "First day of the report (YYYY-MM-DD)")
+	reportCmd.Flags().StringVar(&reportTo, "to", today.Format(tasks.DateLayout), "Last day of the report (YYYY-MM-DD)")
+	reportCmd.Flags().StringVar(&reportBy, "by", tasks.ReportByTask, "Group by task, tag, day or week")
+}
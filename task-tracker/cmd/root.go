@@ -5,12 +5,20 @@ Copyright © 2025 Pranav <pranavppatil767@gmail.com>
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"task-tracker/tasks"
 
 	"github.com/spf13/cobra"
 )
 
-
+// Storage selection, set from --store/--db or the TASK_TRACKER_STORE and
+// TASK_TRACKER_DB environment variables
+var (
+	storeKind string
+	storePath string
+	store     tasks.Store
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -26,20 +34,53 @@ For example:
 ./task-tracker list
 ./task-tracker list done
 ./task-tracker list todo
-./task-tracker list in-progress`,
+./task-tracker list in-progress
+
+Tasks are kept in tasks.json by default. Use --store sqlite (or set
+TASK_TRACKER_STORE=sqlite) to keep them in an embedded SQLite database instead.
+Use --output table|json|csv|markdown to choose how results are printed.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutput()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	err := rootCmd.Execute()
+	if store != nil {
+		store.Close()
+	}
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
+// openStore opens the configured store on first use, so commands such as
+// completion never create a storage file.
+func openStore() tasks.Store {
+	if store == nil {
+		s, err := tasks.Open(storeKind, storePath)
+		if err != nil {
+			fmt.Println("Error opening store:", err)
+			os.Exit(1)
+		}
+		store = s
+	}
+	return store
+}
+
 func init() {
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	rootCmd.PersistentFlags().StringVar(&storeKind, "store", envOr("TASK_TRACKER_STORE", tasks.StoreJSON), "Storage backend (json|sqlite)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputTable, "Output format (table|json|csv|markdown)")
+	rootCmd.PersistentFlags().StringVar(&storePath, "db", os.Getenv("TASK_TRACKER_DB"), "Path to the storage file (default tasks.json or tasks.db)")
 }
 
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
 
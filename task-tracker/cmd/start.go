@@ -0,0 +1,41 @@
+/*
+Copyright © 2025 Pranav <pranavppatil767@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"task-tracker/tasks"
+
+	"github.com/spf13/cobra"
+)
+
+// startCmd represents the start command
+var startCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the timer on a task",
+	Args:  cobra.ExactArgs(1),
+	Long: `Start tracking time on a task by ID and mark it IN-PROGRESS.
+Only one timer can run at a time. For example:
+
+./task-tracker start 1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Println("Invalid ID:", args[0])
+			os.Exit(1)
+		}
+		task, err := tasks.StartTimer(openStore(), id)
+		if err != nil {
+			fmt.Println("Error starting timer:", err)
+			os.Exit(1)
+		}
+		printResult(fmt.Sprint("Timer started on task: ", id), task)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright © 2025 Pranav <pranavppatil767@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"task-tracker/tasks"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// stopCmd represents the stop command
+var stopCmd = &cobra.Command{
+	Use:   "stop [id]",
+	Short: "Stop the running timer",
+	Args:  cobra.MaximumNArgs(1),
+	Long: `Stop the running timer. Without an ID, whichever timer is running is stopped. For example:
+
+./task-tracker stop 1
+./task-tracker stop`,
+	Run: func(cmd *cobra.Command, args []string) {
+		id := 0
+		if len(args) > 0 {
+			var err error
+			id, err = strconv.Atoi(args[0])
+			if err != nil {
+				fmt.Println("Invalid ID:", args[0])
+				os.Exit(1)
+			}
+		}
+		task, err := tasks.StopTimer(openStore(), id)
+		if err != nil {
+			fmt.Println("Error stopping timer:", err)
+			os.Exit(1)
+		}
+		last := task.TimeLog[len(task.TimeLog)-1]
+		start, _ := time.Parse(time.RFC3339, last.Start)
+		end, _ := time.Parse(time.RFC3339, last.End)
+		printResult(fmt.Sprintf("Timer stopped on task %d after %s", task.ID, formatDuration(end.Sub(start))), task)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+}
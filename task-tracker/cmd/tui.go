@@ -0,0 +1,36 @@
+/*
+Copyright © 2025 Pranav <pranavppatil767@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"task-tracker/tui"
+
+	"github.com/spf13/cobra"
+)
+
+// tuiCmd represents the tui command
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Open the interactive kanban board",
+	Args:  cobra.NoArgs,
+	Long: `Open a full-screen board with TO-DO, IN-PROGRESS and DONE columns. Move
+between tasks with the arrow keys (or h/j/k/l), move a task between columns
+with [ and ], add with a, edit with e, filter with / and press ? for every key.
+Changes go through the same store and journal as the other commands. For example:
+
+./task-tracker tui
+./task-tracker --store sqlite tui`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := tui.Run(openStore()); err != nil {
+			fmt.Println("Error running board:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2025 Pranav <pranavppatil767@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"task-tracker/tasks"
+
+	"github.com/spf13/cobra"
+)
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last change",
+	Args:  cobra.NoArgs,
+	Long: `Reverse the most recent change recorded in the journal. Run it again to
+step further back. For example:
+
+./task-tracker delete 3
+./task-tracker undo`,
+	Run: func(cmd *cobra.Command, args []string) {
+		entry, err := tasks.Undo(openStore())
+		if err != nil {
+			fmt.Println("Error undoing:", err)
+			os.Exit(1)
+		}
+		for i, c := range entry.Changes {
+			entry.Changes[i] = c.Reverse()
+		}
+		printEntryResult(fmt.Sprintf("Undid #%d %s", entry.Seq, entry.Op), entry)
+	},
+}
+
+// redoCmd represents the redo command
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Redo the last undone change",
+	Args:  cobra.NoArgs,
+	Long: `Replay the change most recently reversed by undo. Any new change after
+an undo clears what can be redone. For example:
+
+./task-tracker undo
+./task-tracker redo`,
+	Run: func(cmd *cobra.Command, args []string) {
+		entry, err := tasks.Redo(openStore())
+		if err != nil {
+			fmt.Println("Error redoing:", err)
+			os.Exit(1)
+		}
+		printEntryResult(fmt.Sprintf("Redid #%d %s", entry.Seq, entry.Op), entry)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+}
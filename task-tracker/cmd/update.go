@@ -16,26 +16,68 @@ import (
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update a task by ID",
-	Args:  cobra.MinimumNArgs(2),
-	Long: `Update a task by ID For example:
+	Args:  cobra.RangeArgs(1, 2),
+	Long: `Update a task's description or fields by ID. Pass an empty value to clear a field. For example:
 
-./task-tracker update 1 "New Task Description"`,
+./task-tracker update 1 "New Task Description"
+./task-tracker update 1 --priority urgent --due tomorrow
+./task-tracker update 1 --tag "" --project ""
+./task-tracker update 5 --parent 2 --depends-on 3,4`,
 	Run: func(cmd *cobra.Command, args []string) {
 		id, err := strconv.Atoi(args[0])
 		if err != nil {
 			fmt.Println("Invalid ID:", args[0])
 			os.Exit(1)
 		}
-		err = tasks.UpdateTask(id, args[1])
+		flags := cmd.Flags()
+		fieldsChanged := false
+		for _, name := range []string{"priority", "due", "tag", "project", "parent", "depends-on", "every"} {
+			fieldsChanged = fieldsChanged || flags.Changed(name)
+		}
+		if len(args) < 2 && !fieldsChanged {
+			fmt.Println("Nothing to update: pass a new description or at least one field flag")
+			os.Exit(1)
+		}
+		deps, err := tasks.ParseIDs(taskDeps)
+		if err != nil {
+			fmt.Println("Invalid dependencies:", err)
+			os.Exit(1)
+		}
+		task, err := tasks.UpdateTask(openStore(), id, func(t *tasks.Task) {
+			if len(args) > 1 {
+				t.Description = args[1]
+			}
+			if flags.Changed("priority") {
+				t.Priority = taskPriority
+			}
+			if flags.Changed("due") {
+				t.Due = taskDue
+			}
+			if flags.Changed("tag") {
+				t.Tags = taskTags
+			}
+			if flags.Changed("project") {
+				t.Project = taskProject
+			}
+			if flags.Changed("parent") {
+				t.ParentID = taskParent
+			}
+			if flags.Changed("depends-on") {
+				t.DependsOn = deps
+			}
+			if flags.Changed("every") {
+				t.Recurrence = taskEvery
+			}
+		})
 		if err != nil {
 			fmt.Println("Error Updating task:", err)
 			os.Exit(1)
 		}
-		fmt.Println("Task Updated successfully:", id)
-
+		printResult(fmt.Sprint("Task Updated successfully: ", id), task)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(updateCmd)
+	addTaskFieldFlags(updateCmd)
 }
@@ -0,0 +1,184 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// StatusBlocked is derived, never stored: an unfinished task shows as
+// BLOCKED while any task it depends on is not DONE.
+const StatusBlocked = "BLOCKED"
+
+var (
+	// ErrOpenSubtasks is returned when completing a task whose subtasks are still open.
+	ErrOpenSubtasks = errors.New("task has open subtasks")
+	// ErrBlocked is returned when completing a task whose dependencies are unfinished.
+	ErrBlocked = errors.New("task is blocked by unfinished dependencies")
+)
+
+// Node is a task with its subtasks, used to print the hierarchy.
+type Node struct {
+	Task
+	Subtasks []*Node `json:"subtasks,omitempty"`
+}
+
+// BlockedBy returns the IDs of t's dependencies that are not DONE.
+func BlockedBy(tasks []Task, t Task) []int {
+	var open []int
+	for _, dep := range t.DependsOn {
+		if i := indexOf(tasks, dep); i >= 0 && tasks[i].Status != StatusDone {
+			open = append(open, dep)
+		}
+	}
+	return open
+}
+
+// ResolveBlocked returns a copy of tasks with the status of every blocked
+// task replaced by StatusBlocked. Use it for display and filtering only;
+// the result must not be written back to a store.
+func ResolveBlocked(tasks []Task) []Task {
+	out := make([]Task, len(tasks))
+	copy(out, tasks)
+	for i, t := range out {
+		if t.Status != StatusDone && len(BlockedBy(tasks, t)) > 0 {
+			out[i].Status = StatusBlocked
+		}
+	}
+	return out
+}
+
+// OpenSubtasks returns the IDs of all descendants of id that are not DONE.
+func OpenSubtasks(tasks []Task, id int) []int {
+	var open []int
+	for _, t := range tasks {
+		if t.ParentID != id {
+			continue
+		}
+		if t.Status != StatusDone {
+			open = append(open, t.ID)
+		}
+		open = append(open, OpenSubtasks(tasks, t.ID)...)
+	}
+	return open
+}
+
+// BuildTree arranges tasks into a forest. A task whose parent is not in
+// the slice becomes a root, so filtered lists still print sensibly.
+func BuildTree(tasks []Task) []*Node {
+	nodes := make(map[int]*Node, len(tasks))
+	for _, t := range tasks {
+		nodes[t.ID] = &Node{Task: t}
+	}
+	var roots []*Node
+	for _, t := range tasks {
+		n := nodes[t.ID]
+		if parent, ok := nodes[t.ParentID]; ok && t.ParentID != t.ID {
+			parent.Subtasks = append(parent.Subtasks, n)
+		} else {
+			roots = append(roots, n)
+		}
+	}
+	return roots
+}
+
+// checkLinks verifies that t's parent and dependencies exist and that
+// neither introduces a cycle.
+func checkLinks(tasks []Task, t Task) error {
+	if t.ParentID != 0 {
+		if t.ParentID == t.ID {
+			return fmt.Errorf("task %d cannot be its own parent", t.ID)
+		}
+		if indexOf(tasks, t.ParentID) < 0 {
+			return fmt.Errorf("parent %w: %d", ErrTaskNotFound, t.ParentID)
+		}
+		// Walk up from the new parent; reaching t means a cycle
+		seen := map[int]bool{}
+		for p := t.ParentID; p != 0 && !seen[p]; {
+			if p == t.ID {
+				return fmt.Errorf("task %d cannot be a subtask of its own subtask %d", t.ID, t.ParentID)
+			}
+			seen[p] = true
+			i := indexOf(tasks, p)
+			if i < 0 {
+				break
+			}
+			p = tasks[i].ParentID
+		}
+	}
+
+	for _, dep := range t.DependsOn {
+		if dep == t.ID {
+			return fmt.Errorf("task %d cannot depend on itself", t.ID)
+		}
+		if indexOf(tasks, dep) < 0 {
+			return fmt.Errorf("dependency %w: %d", ErrTaskNotFound, dep)
+		}
+		if dependsOn(tasks, dep, t.ID, map[int]bool{}) {
+			return fmt.Errorf("dependency on %d would create a cycle", dep)
+		}
+	}
+	return nil
+}
+
+// dependsOn reports whether from transitively depends on target.
+func dependsOn(tasks []Task, from, target int, seen map[int]bool) bool {
+	if seen[from] {
+		return false
+	}
+	seen[from] = true
+	i := indexOf(tasks, from)
+	if i < 0 {
+		return false
+	}
+	for _, dep := range tasks[i].DependsOn {
+		if dep == target || dependsOn(tasks, dep, target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// unlink removes every reference to id: its subtasks move up to id's
+// parent and it is dropped from other tasks' dependencies.
+func unlink(tasks []Task, id, parentID int) {
+	for i := range tasks {
+		if tasks[i].ParentID == id {
+			tasks[i].ParentID = parentID
+		}
+		tasks[i].DependsOn = slices.DeleteFunc(tasks[i].DependsOn, func(dep int) bool { return dep == id })
+		if len(tasks[i].DependsOn) == 0 {
+			tasks[i].DependsOn = nil
+		}
+	}
+}
+
+// ParseIDs parses a comma separated list of task IDs. An empty string
+// yields no IDs.
+func ParseIDs(s string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid task ID %q", part)
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,268 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Entry is one mutation recorded in the append-only journal. Changes hold
+// full before/after snapshots so any entry can be reversed or replayed.
+type Entry struct {
+	Seq     int      `json:"seq"`
+	Time    string   `json:"time"`
+	Op      string   `json:"op"`
+	Undoes  int      `json:"undoes,omitempty"`
+	Redoes  int      `json:"redoes,omitempty"`
+	Changes []Change `json:"changes"`
+}
+
+// Change is the effect of an entry on a single task. Before is nil for a
+// created task and After is nil for a deleted one.
+type Change struct {
+	ID     int   `json:"id"`
+	Before *Task `json:"before,omitempty"`
+	After  *Task `json:"after,omitempty"`
+}
+
+var (
+	// ErrNothingToUndo is returned by Undo when the journal has no undoable entry.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo is returned by Redo when no undone entry can be replayed.
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// Undo reverses the most recent mutation that has not been undone yet and
+// returns the journal entry it reversed.
+func Undo(s Store) (Entry, error) {
+	return rewind(s, "undo", func(undo, _ []Entry) (Entry, bool) {
+		if len(undo) == 0 {
+			return Entry{}, false
+		}
+		return undo[len(undo)-1], true
+	})
+}
+
+// Redo replays the most recently undone mutation and returns the journal
+// entry it replayed. Any new mutation after an undo clears the redo stack.
+func Redo(s Store) (Entry, error) {
+	return rewind(s, "redo", func(_, redo []Entry) (Entry, bool) {
+		if len(redo) == 0 {
+			return Entry{}, false
+		}
+		return redo[len(redo)-1], true
+	})
+}
+
+func rewind(s Store, op string, pick func(undo, redo []Entry) (Entry, bool)) (Entry, error) {
+	var target Entry
+	err := s.UpdateWithJournal(func(tasks []Task, journal []Entry) ([]Task, Entry, error) {
+		var ok bool
+		target, ok = pick(undoStacks(journal))
+		if !ok {
+			if op == "undo" {
+				return nil, Entry{}, ErrNothingToUndo
+			}
+			return nil, Entry{}, ErrNothingToRedo
+		}
+		entry := Entry{Op: op}
+		var err error
+		if op == "undo" {
+			entry.Undoes = target.Seq
+			tasks, err = applyChanges(tasks, target.Changes, true)
+		} else {
+			entry.Redoes = target.Seq
+			tasks, err = applyChanges(tasks, target.Changes, false)
+		}
+		if err != nil {
+			return nil, Entry{}, fmt.Errorf("cannot %s #%d %s: %w", op, target.Seq, target.Op, err)
+		}
+		return tasks, entry, nil
+	})
+	if err != nil {
+		return Entry{}, err
+	}
+	return target, nil
+}
+
+// undoStacks replays the journal and returns the entries that can be undone
+// and redone, most recent last.
+func undoStacks(journal []Entry) (undo, redo []Entry) {
+	bySeq := make(map[int]Entry, len(journal))
+	for _, e := range journal {
+		bySeq[e.Seq] = e
+		switch {
+		case e.Undoes != 0:
+			if n := len(undo); n > 0 && undo[n-1].Seq == e.Undoes {
+				undo = undo[:n-1]
+			}
+			redo = append(redo, bySeq[e.Undoes])
+		case e.Redoes != 0:
+			if n := len(redo); n > 0 && redo[n-1].Seq == e.Redoes {
+				redo = redo[:n-1]
+			}
+			undo = append(undo, bySeq[e.Redoes])
+		default:
+			undo = append(undo, e)
+			redo = nil
+		}
+	}
+	return undo, redo
+}
+
+// applyChanges moves each changed task back to its Before state (reverse)
+// or forward to its After state. It refuses if a task no longer matches
+// the state the change left it in.
+func applyChanges(tasks []Task, changes []Change, reverse bool) ([]Task, error) {
+	for _, c := range changes {
+		from, to := c.Before, c.After
+		if reverse {
+			from, to = to, from
+		}
+		i := indexOf(tasks, c.ID)
+		switch {
+		case from == nil && i >= 0:
+			return nil, fmt.Errorf("task %d already exists", c.ID)
+		case from != nil && i < 0:
+			return nil, fmt.Errorf("%w: %d", ErrTaskNotFound, c.ID)
+		case from != nil && !sameTask(tasks[i], *from):
+			return nil, fmt.Errorf("task %d has changed since", c.ID)
+		}
+		switch {
+		case to == nil:
+			tasks = append(tasks[:i], tasks[i+1:]...)
+		case i < 0:
+			tasks = append(tasks, *to)
+		default:
+			tasks[i] = *to
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// newEntry records the difference between two task lists. It returns false
+// when nothing changed, in which case nothing should be journaled.
+func newEntry(entry Entry, before, after []Task) (Entry, bool) {
+	old := make(map[int]Task, len(before))
+	for _, t := range before {
+		old[t.ID] = t
+	}
+	entry.Changes = nil
+	for _, t := range after {
+		prev, existed := old[t.ID]
+		delete(old, t.ID)
+		if existed && sameTask(prev, t) {
+			continue
+		}
+		c := Change{ID: t.ID, After: ptr(t)}
+		if existed {
+			c.Before = ptr(prev)
+		}
+		entry.Changes = append(entry.Changes, c)
+	}
+	for _, t := range before {
+		if _, removed := old[t.ID]; removed {
+			entry.Changes = append(entry.Changes, Change{ID: t.ID, Before: ptr(t)})
+		}
+	}
+	if len(entry.Changes) == 0 {
+		return Entry{}, false
+	}
+	sort.Slice(entry.Changes, func(i, j int) bool { return entry.Changes[i].ID < entry.Changes[j].ID })
+	entry.Time = time.Now().Format(time.RFC3339)
+	return entry, true
+}
+
+// EntriesFor returns the journal entries that touched the given task.
+func EntriesFor(journal []Entry, id int) []Entry {
+	var out []Entry
+	for _, e := range journal {
+		for _, c := range e.Changes {
+			if c.ID == id {
+				out = append(out, e)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Reverse returns the change that undoes c.
+func (c Change) Reverse() Change {
+	return Change{ID: c.ID, Before: c.After, After: c.Before}
+}
+
+// historyFields are compared, in this order, when describing a change
+var historyFields = []string{"description", "status", "priority", "due", "recurrence", "project", "tags", "parent_id", "depends_on"}
+
+// Describe summarises what a change did to its task, one line per field,
+// e.g. "status: TO-DO -> DONE".
+func (c Change) Describe() []string {
+	switch {
+	case c.Before == nil:
+		return []string{fmt.Sprintf("created %q", c.After.Description)}
+	case c.After == nil:
+		return []string{fmt.Sprintf("deleted %q", c.Before.Description)}
+	}
+	before, after := fieldMap(*c.Before), fieldMap(*c.After)
+	var lines []string
+	for _, f := range historyFields {
+		if !bytes.Equal(before[f], after[f]) {
+			lines = append(lines, fmt.Sprintf("%s: %s -> %s", f, showField(before[f]), showField(after[f])))
+		}
+	}
+	switch b, a := len(c.Before.TimeLog), len(c.After.TimeLog); {
+	case a > b:
+		lines = append(lines, "timer started")
+	case a == b && a > 0 && c.Before.Running() && !c.After.Running():
+		lines = append(lines, "timer stopped")
+	case a < b:
+		lines = append(lines, "time log changed")
+	}
+	return lines
+}
+
+func fieldMap(t Task) map[string]json.RawMessage {
+	data, _ := json.Marshal(t)
+	m := map[string]json.RawMessage{}
+	json.Unmarshal(data, &m)
+	return m
+}
+
+func showField(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return "(none)"
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// sameTask compares tasks by their JSON form, so nil and empty slices match
+func sameTask(a, b Task) bool {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return bytes.Equal(ja, jb)
+}
+
+// cloneTasks deep-copies tasks so a snapshot survives in-place edits
+func cloneTasks(tasks []Task) []Task {
+	out := make([]Task, len(tasks))
+	for i, t := range tasks {
+		t.Tags = append([]string(nil), t.Tags...)
+		t.DependsOn = append([]int(nil), t.DependsOn...)
+		t.TimeLog = append([]Interval(nil), t.TimeLog...)
+		out[i] = t
+	}
+	return out
+}
+
+func ptr(t Task) *Task {
+	return &t
+}
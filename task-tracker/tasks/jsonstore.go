@@ -0,0 +1,209 @@
+package tasks
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gofrs/flock"
+)
+
+// JSONStore keeps tasks in a single JSON file. Writes go to a temporary
+// file that is renamed over the original, and every operation holds an
+// advisory lock on a sibling ".lock" file so concurrent shells serialise.
+// The journal is a sibling ".journal" file with one JSON entry per line.
+type JSONStore struct {
+	path    string
+	journal string
+	lock    *flock.Flock
+}
+
+// NewJSONStore returns a store backed by the JSON file at path.
+func NewJSONStore(path string) *JSONStore {
+	return &JSONStore{
+		path:    path,
+		journal: path + ".journal",
+		lock:    flock.New(path + ".lock"),
+	}
+}
+
+// Load returns every task in the file.
+func (s *JSONStore) Load() ([]Task, error) {
+	if err := s.lock.RLock(); err != nil {
+		return nil, fmt.Errorf("locking %s: %w", s.path, err)
+	}
+	defer s.lock.Unlock()
+	return s.read()
+}
+
+// Update applies fn to the stored tasks, atomically replaces the file and
+// journals the change.
+func (s *JSONStore) Update(op string, fn func([]Task) ([]Task, error)) error {
+	return s.UpdateWithJournal(func(tasks []Task, _ []Entry) ([]Task, Entry, error) {
+		tasks, err := fn(tasks)
+		return tasks, Entry{Op: op}, err
+	})
+}
+
+// UpdateWithJournal is Update with the journal passed to fn.
+func (s *JSONStore) UpdateWithJournal(fn func([]Task, []Entry) ([]Task, Entry, error)) error {
+	if err := s.lock.Lock(); err != nil {
+		return fmt.Errorf("locking %s: %w", s.path, err)
+	}
+	defer s.lock.Unlock()
+
+	before, err := s.read()
+	if err != nil {
+		return err
+	}
+	journal, err := s.readJournal()
+	if err != nil {
+		return err
+	}
+	after, entry, err := fn(cloneTasks(before), journal)
+	if err != nil {
+		return err
+	}
+	entry, changed := newEntry(entry, before, after)
+	if !changed {
+		return nil
+	}
+	entry.Seq = 1
+	if n := len(journal); n > 0 {
+		entry.Seq = journal[n-1].Seq + 1
+	}
+
+	// The task file is replaced first so the journal never records a
+	// change that was not made
+	if err := s.write(after); err != nil {
+		return err
+	}
+	return s.appendJournal(entry)
+}
+
+// Journal returns every journal entry.
+func (s *JSONStore) Journal() ([]Entry, error) {
+	if err := s.lock.RLock(); err != nil {
+		return nil, fmt.Errorf("locking %s: %w", s.path, err)
+	}
+	defer s.lock.Unlock()
+	return s.readJournal()
+}
+
+// Close is a no-op; the lock is only held for the duration of a call.
+func (s *JSONStore) Close() error {
+	return nil
+}
+
+func (s *JSONStore) read() ([]Task, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		// If the file does not exist, return an empty slice
+		if os.IsNotExist(err) {
+			return []Task{}, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", s.path, err)
+	}
+	// If the file is empty, return an empty slice
+	if len(data) == 0 {
+		return []Task{}, nil
+	}
+	var tasks []Task
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", s.path, err)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// write marshals tasks into a temp file in the same directory, syncs it and
+// renames it over the storage file, so a crash never leaves a partial file.
+func (s *JSONStore) write(tasks []Task) error {
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// Clean up the temp file if anything below fails
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *JSONStore) readJournal() ([]Entry, error) {
+	f, err := os.Open(s.journal)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var journal []Entry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 64<<20)
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			// A crash mid-append can only damage the last line; ignore it
+			if !sc.Scan() {
+				break
+			}
+			return nil, fmt.Errorf("decoding %s line %d: %w", s.journal, line, err)
+		}
+		journal = append(journal, e)
+	}
+	return journal, sc.Err()
+}
+
+func (s *JSONStore) appendJournal(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.journal, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	// Start on a fresh line if an earlier append was cut short
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			data = append([]byte{'\n'}, data...)
+		}
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
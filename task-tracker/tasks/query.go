@@ -0,0 +1,338 @@
+package tasks
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a parsed filter expression such as
+//
+//	status:todo tag:backend due<2026-11-01 priority>=high
+//
+// Terms are ANDed together. Each term is field, operator, value where the
+// operator is one of : = != < <= > >= (":" and "=" both mean equals).
+// A leading "-" negates a term, and a bare word matches the description.
+type Query struct {
+	terms []term
+}
+
+type term struct {
+	negate bool
+	field  string
+	op     string
+	value  string
+	num    int       // id and priority rank
+	date   time.Time // due, created and updated
+}
+
+var termPattern = regexp.MustCompile(`^(-?)([a-z]+)(:|<=|>=|!=|<|>|=)(.*)$`)
+
+// field kinds decide which operators a field accepts and how values are parsed
+const (
+	kindText = iota
+	kindNumber
+	kindDate
+)
+
+var queryFields = map[string]int{
+	"id":          kindNumber,
+	"status":      kindText,
+	"priority":    kindNumber,
+	"due":         kindDate,
+	"created":     kindDate,
+	"updated":     kindDate,
+	"parent":      kindNumber,
+	"series":      kindNumber,
+	"recurrence":  kindText,
+	"tag":         kindText,
+	"project":     kindText,
+	"description": kindText,
+}
+
+// ParseQuery parses a filter expression. Arguments are split on whitespace,
+// so both a single quoted string and separate shell words work.
+func ParseQuery(args ...string) (Query, error) {
+	var q Query
+	for _, word := range strings.Fields(strings.Join(args, " ")) {
+		t, err := parseTerm(word)
+		if err != nil {
+			return Query{}, err
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+func parseTerm(word string) (term, error) {
+	m := termPattern.FindStringSubmatch(strings.ToLower(word))
+	if m == nil {
+		// Bare word: substring match on the description
+		negate := strings.HasPrefix(word, "-") && len(word) > 1
+		return term{negate: negate, field: "description", op: "=", value: strings.ToLower(strings.TrimPrefix(word, "-"))}, nil
+	}
+	t := term{negate: m[1] == "-", field: m[2], op: m[3], value: m[4]}
+	if t.op == ":" {
+		t.op = "="
+	}
+	if t.field == "desc" {
+		t.field = "description"
+	}
+	kind, ok := queryFields[t.field]
+	if !ok {
+		return term{}, fmt.Errorf("unknown filter field %q in %q", t.field, word)
+	}
+	if kind == kindText && t.op != "=" && t.op != "!=" {
+		return term{}, fmt.Errorf("field %q only supports : and != in %q", t.field, word)
+	}
+	if t.value == "none" {
+		if t.op != "=" && t.op != "!=" {
+			return term{}, fmt.Errorf("\"none\" only supports : and != in %q", word)
+		}
+		return t, nil
+	}
+
+	var err error
+	switch t.field {
+	case "id", "parent", "series":
+		t.num, err = strconv.Atoi(t.value)
+	case "status":
+		t.value, err = ParseStatus(t.value)
+	case "priority":
+		t.value, err = ParsePriority(t.value)
+		t.num = priorityRank(t.value)
+	case "due", "created", "updated":
+		t.date, err = parseDate(t.value)
+	}
+	if err != nil {
+		return term{}, fmt.Errorf("bad value in %q: %w", word, err)
+	}
+	return t, nil
+}
+
+// Match reports whether the task satisfies every term of the query.
+func (q Query) Match(t Task) bool {
+	for _, tm := range q.terms {
+		if tm.match(t) == tm.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the tasks that match the query.
+func (q Query) Filter(tasks []Task) []Task {
+	out := make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		if q.Match(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (tm term) match(t Task) bool {
+	if tm.value == "none" {
+		empty := fieldIsEmpty(t, tm.field)
+		return empty == (tm.op == "=")
+	}
+	switch tm.field {
+	case "id":
+		return compare(t.ID-tm.num, tm.op)
+	case "parent":
+		return compare(t.ParentID-tm.num, tm.op)
+	case "series":
+		// The first task of a series has no SeriesID of its own
+		series := t.SeriesID
+		if series == 0 && t.Recurrence != "" {
+			series = t.ID
+		}
+		return compare(series-tm.num, tm.op)
+	case "recurrence":
+		return strings.Contains(t.Recurrence, tm.value) == (tm.op == "=")
+	case "status":
+		return (t.Status == tm.value) == (tm.op == "=")
+	case "priority":
+		rank := priorityRank(t.Priority)
+		if rank == 0 {
+			return tm.op == "!="
+		}
+		return compare(rank-tm.num, tm.op)
+	case "due", "created", "updated":
+		d, ok := taskDate(t, tm.field)
+		if !ok {
+			return tm.op == "!="
+		}
+		return compare(d.Compare(tm.date), tm.op)
+	case "tag":
+		return t.HasTag(tm.value) == (tm.op == "=")
+	case "project":
+		return strings.EqualFold(t.Project, tm.value) == (tm.op == "=")
+	case "description":
+		contains := strings.Contains(strings.ToLower(t.Description), tm.value)
+		return contains == (tm.op == "=")
+	}
+	return false
+}
+
+// compare interprets the sign of diff against op
+func compare(diff int, op string) bool {
+	switch op {
+	case "=":
+		return diff == 0
+	case "!=":
+		return diff != 0
+	case "<":
+		return diff < 0
+	case "<=":
+		return diff <= 0
+	case ">":
+		return diff > 0
+	case ">=":
+		return diff >= 0
+	}
+	return false
+}
+
+func fieldIsEmpty(t Task, field string) bool {
+	switch field {
+	case "priority":
+		return t.Priority == ""
+	case "due":
+		return t.Due == ""
+	case "tag":
+		return len(t.Tags) == 0
+	case "project":
+		return t.Project == ""
+	case "parent":
+		return t.ParentID == 0
+	case "recurrence":
+		return t.Recurrence == ""
+	case "description":
+		return t.Description == ""
+	}
+	return false
+}
+
+// taskDate returns the calendar day of a date field.
+func taskDate(t Task, field string) (time.Time, bool) {
+	var raw string
+	switch field {
+	case "due":
+		raw = t.Due
+	case "created":
+		raw = t.CreatedAt
+	case "updated":
+		raw = t.UpdatedAt
+	}
+	if raw == "" {
+		return time.Time{}, false
+	}
+	if len(raw) > len(DateLayout) {
+		raw = raw[:len(DateLayout)]
+	}
+	d, err := time.Parse(DateLayout, raw)
+	return d, err == nil
+}
+
+// SortTasks orders tasks by the given keys, e.g. "priority" or "-due".
+// A leading "-" sorts that key descending. Tasks missing a value always
+// sort after those that have one. ID is the final tie-breaker.
+func SortTasks(tasks []Task, keys []string) error {
+	type sortKey struct {
+		field string
+		desc  bool
+	}
+	var parsed []sortKey
+	for _, k := range keys {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "" {
+			continue
+		}
+		sk := sortKey{field: strings.TrimPrefix(k, "-"), desc: strings.HasPrefix(k, "-")}
+		if sk.field == "desc" {
+			sk.field = "description"
+		}
+		if _, ok := queryFields[sk.field]; !ok || sk.field == "tag" || sk.field == "recurrence" {
+			return fmt.Errorf("unknown sort key %q", k)
+		}
+		parsed = append(parsed, sk)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, k := range parsed {
+			c, decided := compareField(tasks[i], tasks[j], k.field)
+			if decided {
+				return c < 0
+			}
+			if c != 0 {
+				if k.desc {
+					return c > 0
+				}
+				return c < 0
+			}
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return nil
+}
+
+// compareField returns the ordering of a and b on field. decided is true
+// when exactly one side is missing the value, in which case the order
+// ignores the sort direction.
+func compareField(a, b Task, field string) (c int, decided bool) {
+	switch field {
+	case "id":
+		return a.ID - b.ID, false
+	case "parent":
+		return a.ParentID - b.ParentID, false
+	case "series":
+		return a.SeriesID - b.SeriesID, false
+	case "status":
+		return statusRank(a.Status) - statusRank(b.Status), false
+	case "priority":
+		ra, rb := priorityRank(a.Priority), priorityRank(b.Priority)
+		if (ra == 0) != (rb == 0) {
+			return rb - ra, true
+		}
+		return ra - rb, false
+	case "due", "created", "updated":
+		da, oka := taskDate(a, field)
+		db, okb := taskDate(b, field)
+		if oka != okb {
+			if oka {
+				return -1, true
+			}
+			return 1, true
+		}
+		return da.Compare(db), false
+	case "project":
+		if (a.Project == "") != (b.Project == "") {
+			if a.Project != "" {
+				return -1, true
+			}
+			return 1, true
+		}
+		return strings.Compare(strings.ToLower(a.Project), strings.ToLower(b.Project)), false
+	case "description":
+		return strings.Compare(strings.ToLower(a.Description), strings.ToLower(b.Description)), false
+	}
+	return 0, false
+}
+
+func statusRank(s string) int {
+	switch s {
+	case StatusTodo:
+		return 0
+	case StatusInProgress:
+		return 1
+	case StatusBlocked:
+		return 2
+	case StatusDone:
+		return 3
+	}
+	return 4
+}
@@ -0,0 +1,339 @@
+package tasks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence is a parsed repeat rule. Rules come in two forms:
+//
+//	daily | weekly | monthly | yearly | weekdays
+//	every 2 weeks | every 3 days | every month
+//	weekly on mon,thu | monthly on 15
+//
+// or a five-field cron expression such as "0 9 * * 1". Tasks are due on
+// dates, so the minute and hour fields of a cron rule are ignored.
+type Recurrence struct {
+	unit     string // day, week, month, year; empty for cron rules
+	interval int
+	weekdays map[time.Weekday]bool
+	monthDay int
+	cron     *cronDays
+}
+
+// cronDays holds the date fields of a cron expression
+type cronDays struct {
+	dom, month, dow map[int]bool
+	domAny, dowAny  bool
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseRecurrence parses a repeat rule.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	words := strings.Fields(strings.ToLower(rule))
+	if len(words) == 5 && isCronField(words[0]) {
+		return parseCron(words)
+	}
+	bad := fmt.Errorf("invalid recurrence %q (try daily, weekly on mon, every 2 weeks, monthly on 15 or a cron expression)", rule)
+	if len(words) == 0 {
+		return Recurrence{}, bad
+	}
+
+	r := Recurrence{interval: 1}
+	rest := words[1:]
+	switch words[0] {
+	case "daily":
+		r.unit = "day"
+	case "weekly":
+		r.unit = "week"
+	case "monthly":
+		r.unit = "month"
+	case "yearly", "annually":
+		r.unit = "year"
+	case "weekdays":
+		r.unit = "week"
+		r.weekdays = map[time.Weekday]bool{time.Monday: true, time.Tuesday: true, time.Wednesday: true, time.Thursday: true, time.Friday: true}
+	case "every":
+		if len(rest) > 0 {
+			if n, err := strconv.Atoi(rest[0]); err == nil && n > 0 {
+				r.interval = n
+				rest = rest[1:]
+			}
+		}
+		if len(rest) == 0 {
+			return Recurrence{}, bad
+		}
+		r.unit = strings.TrimSuffix(rest[0], "s")
+		rest = rest[1:]
+		switch r.unit {
+		case "day", "week", "month", "year":
+		default:
+			return Recurrence{}, bad
+		}
+	default:
+		return Recurrence{}, bad
+	}
+
+	// Optional "on ..." qualifier
+	if len(rest) > 0 {
+		if rest[0] != "on" || len(rest) < 2 || r.weekdays != nil {
+			return Recurrence{}, bad
+		}
+		spec := strings.Join(rest[1:], ",")
+		switch r.unit {
+		case "week":
+			r.weekdays = map[time.Weekday]bool{}
+			for _, name := range strings.Split(spec, ",") {
+				name = strings.TrimSpace(name)
+				if name == "" {
+					continue
+				}
+				if len(name) > 3 {
+					name = name[:3]
+				}
+				day, ok := weekdayNames[name]
+				if !ok {
+					return Recurrence{}, bad
+				}
+				r.weekdays[day] = true
+			}
+		case "month":
+			day, err := strconv.Atoi(strings.TrimRight(spec, "stndrh"))
+			if err != nil || day < 1 || day > 31 {
+				return Recurrence{}, bad
+			}
+			r.monthDay = day
+		default:
+			return Recurrence{}, bad
+		}
+	}
+	return r, nil
+}
+
+// cronSearchYears bounds the day-by-day search for the next cron match.
+// Eight years always covers a Feb 29, even across a skipped leap year.
+const cronSearchYears = 8
+
+// First returns the first occurrence on or after from.
+func (r Recurrence) First(from time.Time) (time.Time, error) {
+	return r.Next(from.AddDate(0, 0, -1))
+}
+
+// Next returns the first occurrence strictly after the given date.
+func (r Recurrence) Next(after time.Time) (time.Time, error) {
+	if r.cron != nil {
+		limit := after.AddDate(cronSearchYears, 0, 0)
+		for d := after.AddDate(0, 0, 1); !d.After(limit); d = d.AddDate(0, 0, 1) {
+			if r.cron.matches(d) {
+				return d, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("cron rule has no occurrence within %d years", cronSearchYears)
+	}
+	return r.next(after), nil
+}
+
+func (r Recurrence) next(after time.Time) time.Time {
+	switch {
+	case len(r.weekdays) > 0:
+		// Next listed weekday in this week, else jump interval weeks ahead
+		// to the first listed weekday of that week
+		d := after.AddDate(0, 0, 1)
+		for ; d.Weekday() != time.Monday; d = d.AddDate(0, 0, 1) {
+			if r.weekdays[d.Weekday()] {
+				return d
+			}
+		}
+		d = d.AddDate(0, 0, 7*(r.interval-1))
+		for !r.weekdays[d.Weekday()] {
+			d = d.AddDate(0, 0, 1)
+		}
+		return d
+	case r.monthDay > 0:
+		// This month if the day is still ahead, otherwise interval months on.
+		// Short months clamp to their last day.
+		month := time.Date(after.Year(), after.Month(), 1, 0, 0, 0, 0, after.Location())
+		if d := clampDay(month, r.monthDay); d.After(after) {
+			return d
+		}
+		return clampDay(month.AddDate(0, r.interval, 0), r.monthDay)
+	}
+	switch r.unit {
+	case "day":
+		return after.AddDate(0, 0, r.interval)
+	case "week":
+		return after.AddDate(0, 0, 7*r.interval)
+	case "month":
+		return after.AddDate(0, r.interval, 0)
+	default:
+		return after.AddDate(r.interval, 0, 0)
+	}
+}
+
+func clampDay(month time.Time, day int) time.Time {
+	last := month.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, month.Location())
+}
+
+func isCronField(s string) bool {
+	return strings.Trim(s, "0123456789*,/-") == ""
+}
+
+func parseCron(fields []string) (Recurrence, error) {
+	for _, f := range fields[:2] {
+		if !isCronField(f) {
+			return Recurrence{}, fmt.Errorf("invalid cron field %q", f)
+		}
+	}
+	dom, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	month, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	dow, err := parseCronField(fields[4], 0, 7)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	// Both 0 and 7 mean Sunday
+	if dow[7] {
+		dow[0] = true
+	}
+	c := &cronDays{
+		dom: dom, month: month, dow: dow,
+		domAny: fields[2] == "*", dowAny: fields[4] == "*",
+	}
+	if !c.possible() {
+		return Recurrence{}, fmt.Errorf("cron expression %q never matches a date", strings.Join(fields, " "))
+	}
+	return Recurrence{cron: c}, nil
+}
+
+// daysInMonth is the longest each month can be, counting Feb 29
+var daysInMonth = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// possible reports whether any date can match. Only a restricted
+// day-of-month with an unrestricted day-of-week can rule out every day,
+// e.g. "0 0 31 2 *".
+func (c *cronDays) possible() bool {
+	if c.domAny || !c.dowAny {
+		return true
+	}
+	for m := range c.month {
+		for d := range c.dom {
+			if d <= daysInMonth[m] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseCronField expands "*", "a-b", "a,b" and "/step" into a set
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	set := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if base, s, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid cron step in %q", field)
+			}
+			part, step = base, n
+		}
+		lo, hi := min, max
+		if part != "*" {
+			from, to, isRange := strings.Cut(part, "-")
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return nil, fmt.Errorf("invalid cron field %q", field)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return nil, fmt.Errorf("invalid cron field %q", field)
+				}
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("cron field %q out of range %d-%d", field, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// matches follows cron semantics: when both day-of-month and day-of-week
+// are restricted, a date matching either one qualifies.
+func (c *cronDays) matches(d time.Time) bool {
+	if !c.month[int(d.Month())] {
+		return false
+	}
+	domOK, dowOK := c.dom[d.Day()], c.dow[int(d.Weekday())]
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowOK
+	case c.dowAny:
+		return domOK
+	}
+	return domOK || dowOK
+}
+
+// nextOccurrence builds the task that follows a completed recurring task.
+// The new due date follows the schedule from the old one, skipping dates
+// that are already in the past.
+func nextOccurrence(tasks []Task, done Task) (Task, error) {
+	rule, err := ParseRecurrence(done.Recurrence)
+	if err != nil {
+		return Task{}, err
+	}
+	today, _ := parseDate("today")
+	base := today
+	if done.Due != "" {
+		if base, err = parseDate(done.Due); err != nil {
+			return Task{}, err
+		}
+	}
+	next, err := rule.Next(base)
+	for err == nil && next.Before(today) {
+		next, err = rule.Next(next)
+	}
+	if err != nil {
+		return Task{}, err
+	}
+
+	series := done.SeriesID
+	if series == 0 {
+		series = done.ID
+	}
+	currentTime := time.Now().Format(time.RFC3339)
+	return Task{
+		ID:          nextID(tasks),
+		Description: done.Description,
+		Status:      StatusTodo,
+		Priority:    done.Priority,
+		Due:         next.Format(DateLayout),
+		Tags:        append([]string(nil), done.Tags...),
+		Project:     done.Project,
+		ParentID:    done.ParentID,
+		Recurrence:  done.Recurrence,
+		SeriesID:    series,
+		CreatedAt:   currentTime,
+		UpdatedAt:   currentTime,
+	}, nil
+}
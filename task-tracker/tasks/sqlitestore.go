@@ -0,0 +1,261 @@
+package tasks
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore keeps tasks in an embedded SQLite database.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// migrations are applied in order; PRAGMA user_version records how many ran.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS tasks (
+		id          INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		status      TEXT NOT NULL,
+		created_at  TEXT NOT NULL,
+		updated_at  TEXT NOT NULL
+	)`,
+	`ALTER TABLE tasks ADD COLUMN priority TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE tasks ADD COLUMN due TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE tasks ADD COLUMN tags TEXT NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE tasks ADD COLUMN project TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE tasks ADD COLUMN parent_id INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE tasks ADD COLUMN depends_on TEXT NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE tasks ADD COLUMN time_log TEXT NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE tasks ADD COLUMN recurrence TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE tasks ADD COLUMN series_id INTEGER NOT NULL DEFAULT 0`,
+	`CREATE TABLE IF NOT EXISTS journal (
+		seq     INTEGER PRIMARY KEY AUTOINCREMENT,
+		time    TEXT NOT NULL,
+		op      TEXT NOT NULL,
+		undoes  INTEGER NOT NULL DEFAULT 0,
+		redoes  INTEGER NOT NULL DEFAULT 0,
+		changes TEXT NOT NULL
+	)`,
+}
+
+// OpenSQLiteStore opens (creating if needed) the database at path and
+// brings its schema up to date.
+func OpenSQLiteStore(path string) (*SQLiteStore, error) {
+	// Immediate transactions take the write lock up front, so two shells
+	// updating at once queue on busy_timeout instead of failing mid-way.
+	dsn := fmt.Sprintf("file:%s?_txlock=immediate&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	s := &SQLiteStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *SQLiteStore) migrate() error {
+	var version int
+	if err := s.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return err
+		}
+		// PRAGMA does not accept bound parameters
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load returns every task in the database.
+func (s *SQLiteStore) Load() ([]Task, error) {
+	return loadTasks(s.db)
+}
+
+// Update applies fn inside a single transaction, writes back the tasks that
+// changed and journals the change.
+func (s *SQLiteStore) Update(op string, fn func([]Task) ([]Task, error)) error {
+	return s.update(false, func(tasks []Task, _ []Entry) ([]Task, Entry, error) {
+		tasks, err := fn(tasks)
+		return tasks, Entry{Op: op}, err
+	})
+}
+
+// UpdateWithJournal is Update with the journal passed to fn.
+func (s *SQLiteStore) UpdateWithJournal(fn func([]Task, []Entry) ([]Task, Entry, error)) error {
+	return s.update(true, fn)
+}
+
+func (s *SQLiteStore) update(withJournal bool, fn func([]Task, []Entry) ([]Task, Entry, error)) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := loadTasks(tx)
+	if err != nil {
+		return err
+	}
+	var journal []Entry
+	if withJournal {
+		if journal, err = loadJournal(tx); err != nil {
+			return err
+		}
+	}
+	after, entry, err := fn(cloneTasks(before), journal)
+	if err != nil {
+		return err
+	}
+	entry, changed := newEntry(entry, before, after)
+	if !changed {
+		return nil
+	}
+
+	for _, c := range entry.Changes {
+		if c.Before != nil {
+			if _, err := tx.Exec(`DELETE FROM tasks WHERE id = ?`, c.ID); err != nil {
+				return err
+			}
+		}
+		if c.After != nil {
+			if err := insertTask(tx, *c.After); err != nil {
+				return err
+			}
+		}
+	}
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		`INSERT INTO journal (time, op, undoes, redoes, changes) VALUES (?, ?, ?, ?, ?)`,
+		entry.Time, entry.Op, entry.Undoes, entry.Redoes, string(changes),
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Journal returns every journal entry.
+func (s *SQLiteStore) Journal() ([]Entry, error) {
+	return loadJournal(s.db)
+}
+
+// Close closes the underlying database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func loadTasks(q queryer) ([]Task, error) {
+	rows, err := q.Query(`SELECT id, description, status, priority, due, tags, project, parent_id, depends_on, time_log, recurrence, series_id, created_at, updated_at FROM tasks ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []Task{}
+	for rows.Next() {
+		var t Task
+		var tags, dependsOn, timeLog string
+		if err := rows.Scan(&t.ID, &t.Description, &t.Status, &t.Priority, &t.Due, &tags, &t.Project, &t.ParentID, &dependsOn, &timeLog, &t.Recurrence, &t.SeriesID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if err := decodeList(tags, &t.Tags); err != nil {
+			return nil, fmt.Errorf("decoding tags of task %d: %w", t.ID, err)
+		}
+		if err := decodeList(dependsOn, &t.DependsOn); err != nil {
+			return nil, fmt.Errorf("decoding dependencies of task %d: %w", t.ID, err)
+		}
+		if err := decodeList(timeLog, &t.TimeLog); err != nil {
+			return nil, fmt.Errorf("decoding time log of task %d: %w", t.ID, err)
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+func loadJournal(q queryer) ([]Entry, error) {
+	rows, err := q.Query(`SELECT seq, time, op, undoes, redoes, changes FROM journal ORDER BY seq`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var journal []Entry
+	for rows.Next() {
+		var e Entry
+		var changes string
+		if err := rows.Scan(&e.Seq, &e.Time, &e.Op, &e.Undoes, &e.Redoes, &changes); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(changes), &e.Changes); err != nil {
+			return nil, fmt.Errorf("decoding journal entry %d: %w", e.Seq, err)
+		}
+		journal = append(journal, e)
+	}
+	return journal, rows.Err()
+}
+
+func insertTask(tx *sql.Tx, t Task) error {
+	tags, err := encodeList(t.Tags)
+	if err != nil {
+		return err
+	}
+	dependsOn, err := encodeList(t.DependsOn)
+	if err != nil {
+		return err
+	}
+	timeLog, err := encodeList(t.TimeLog)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		`INSERT INTO tasks (id, description, status, priority, due, tags, project, parent_id, depends_on, time_log, recurrence, series_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.ID, t.Description, t.Status, t.Priority, t.Due, tags, t.Project, t.ParentID, dependsOn, timeLog, t.Recurrence, t.SeriesID, t.CreatedAt, t.UpdatedAt,
+	)
+	return err
+}
+
+// List columns are stored as JSON arrays
+func encodeList[T any](list []T) (string, error) {
+	if len(list) == 0 {
+		return "[]", nil
+	}
+	data, err := json.Marshal(list)
+	return string(data), err
+}
+
+// decodeList leaves dst nil for an empty array so both stores agree
+func decodeList[T any](data string, dst *[]T) error {
+	if data == "" || data == "[]" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data), dst)
+}
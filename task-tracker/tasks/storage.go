@@ -1,175 +1,236 @@
 // Shared task logic
 
 package tasks
+
 import (
 	"fmt"
-	"encoding/json"
-	"os"
+	"strings"
 	"time"
 )
 
-// structure of the task storage file
-type Task struct {
-	ID		  		int    `json:"id"`
-	Description	    string `json:"description"`
-	Status          string `json:"status"`
-	CreatedAt	    string `json:"created_at"`
-	UpdatedAt	    string `json:"updated_at"`
-}
-
-// there will be a file to store tasks
-const storageFile = "tasks.json"
-
-// LoadTasks loads tasks from the storage file
-func LoadTasks() ([]Task, error) {
-	data, err := os.ReadFile(storageFile)
-	if err != nil {
-		// If the file does not exist, return an empty slice
-		if os.IsNotExist(err) {
-			return []Task{}, nil
-		}
-		fmt.Println("Error reading tasks file:", err)
-		return nil, err
-	}
-	//  If the file is empty, return an empty slice
-	if len(data) == 0 {
-		return []Task{}, nil // Return empty slice if file is empty
-	}
-	var tasks []Task
-	err = json.Unmarshal(data, &tasks)
-	if err != nil {
-		fmt.Println("Error unmarshalling tasks:", err)
-		return nil, err
-	}
-	return tasks,nil
+// LoadTasks loads tasks from the store
+func LoadTasks(s Store) ([]Task, error) {
+	return s.Load()
 }
 
-// AddTask adds a new task to the storage file
-func AddTask(Description string) (Task, error) {
-	// get existing tasks
-	tasks, err := LoadTasks()
-	if err != nil {
+// AddTask adds a new task to the store. ID, status and timestamps are
+// assigned here; the optional fields are validated and normalised.
+func AddTask(s Store, task Task) (Task, error) {
+	if err := normalize(&task); err != nil {
 		return Task{}, err
 	}
-	// Create a new task
-	newID := 1
-	for _, t := range tasks {
-		if t.ID >=newID {
-			newID = t.ID +1
+	err := s.Update("add", func(tasks []Task) ([]Task, error) {
+		currentTime := time.Now().Format(time.RFC3339)
+		task.ID = nextID(tasks)
+		if err := checkLinks(tasks, task); err != nil {
+			return nil, err
 		}
-	}
-	currentTime := time.Now().Format(time.RFC3339)
-	task := Task{
-		ID: 		newID,
-		Description: Description,
-		Status:     "TO-DO",
-		CreatedAt:  currentTime,
-		UpdatedAt: currentTime,
-	}
-	tasks = append(tasks, task)
-	// We have entire tasks list, rewrite this to the file
-	data, err := json.MarshalIndent(tasks, "", "  ")
-	if err != nil {
-		return Task{}, err
-	}
-	err = os.WriteFile(storageFile, data, 0644)
+		task.Status = StatusTodo
+		task.CreatedAt = currentTime
+		task.UpdatedAt = currentTime
+		return append(tasks, task), nil
+	})
 	if err != nil {
 		return Task{}, err
 	}
 	return task, nil
 }
 
-// DeleteTask deletes a task by ID
-func DeleteTask(id int) error {
-	tasks, err := LoadTasks()
-	newTasks := make([]Task, 0, len(tasks))
-	for _,t := range tasks{
-		if t.ID !=id {
-			newTasks = append(newTasks, t)
+// DeleteTask deletes a task by ID and returns the removed task. Its
+// subtasks move up to its parent and it stops blocking its dependents.
+func DeleteTask(s Store, id int) (Task, error) {
+	var deleted Task
+	err := s.Update("delete", func(tasks []Task) ([]Task, error) {
+		i := indexOf(tasks, id)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrTaskNotFound, id)
 		}
-	}
-	data, err := json.MarshalIndent(newTasks, "", "  ")
+		deleted = tasks[i]
+		tasks = append(tasks[:i], tasks[i+1:]...)
+		unlink(tasks, id, deleted.ParentID)
+		return tasks, nil
+	})
 	if err != nil {
-		return err
-	}
-	err = os.WriteFile(storageFile, data, 0644)
-	if err != nil {
-		return err
+		return Task{}, err
 	}
-	return nil
+	return deleted, nil
+}
+
+// UpdateTask applies fn to the task with the given ID, validates the result
+// and returns the updated task.
+func UpdateTask(s Store, id int, fn func(t *Task)) (Task, error) {
+	return modifyTask(s, "update", id, fn)
 }
 
-// Update existing task by ID
-func UpdateTask(id int, Description string) error {
-	tasks, err := LoadTasks()
-	newTasks := make([]Task, 0, len(tasks))
-	for _,t := range tasks{
-		if t.ID !=id {
-			newTasks = append(newTasks, t)
-		} else {
-			// Update the task
-			t.Description = Description
-			t.UpdatedAt = time.Now().Format(time.RFC3339)
-			newTasks = append(newTasks, t)
+// Mark a task as done by ID. Unless force is set it refuses while the task
+// has open subtasks or unfinished dependencies. Completing a recurring task
+// creates its next occurrence, which is returned as next; the completed
+// task stays in the store as history.
+func MarkTaskDone(s Store, id int, force bool) (done Task, next *Task, err error) {
+	done, err = modifyTaskIn(s, "mark-done", id, func(tasks []Task, t *Task) error {
+		if !force {
+			if open := OpenSubtasks(tasks, id); len(open) > 0 {
+				return fmt.Errorf("%w: %s (use --force to complete anyway)", ErrOpenSubtasks, joinIDs(open))
+			}
+			if blocked := BlockedBy(tasks, *t); len(blocked) > 0 {
+				return fmt.Errorf("%w: %s (use --force to complete anyway)", ErrBlocked, joinIDs(blocked))
+			}
+		}
+		if t.Recurrence != "" && t.Status != StatusDone {
+			n, err := nextOccurrence(tasks, *t)
+			if err != nil {
+				return err
+			}
+			next = &n
 		}
+		t.Status = StatusDone
+		stopTimer(t)
+		return nil
+	}, func(tasks []Task) []Task {
+		if next != nil {
+			tasks = append(tasks, *next)
+		}
+		return tasks
+	})
+	if err != nil {
+		return Task{}, nil, err
 	}
-	data, err := json.MarshalIndent(newTasks, "", "  ")
+	return done, next, nil
+}
+
+// Mark a task as in-progress by ID
+func MarkTaskInProgress(s Store, id int) (Task, error) {
+	return modifyTask(s, "mark-in-progress", id, func(t *Task) {
+		t.Status = StatusInProgress
+	})
+}
+
+// Mark a task as to-do by ID, e.g. to reopen it
+func MarkTaskTodo(s Store, id int) (Task, error) {
+	return modifyTask(s, "mark-todo", id, func(t *Task) {
+		t.Status = StatusTodo
+	})
+}
+
+// CopyTasks copies every task from src into dst, keeping IDs. It refuses to
+// touch a destination that already holds tasks unless overwrite is set.
+func CopyTasks(src, dst Store, overwrite bool) (int, error) {
+	tasks, err := src.Load()
 	if err != nil {
-		return err
+		return 0, err
 	}
-	err = os.WriteFile(storageFile, data, 0644)
+	err = dst.Update("migrate", func(existing []Task) ([]Task, error) {
+		if len(existing) > 0 && !overwrite {
+			return nil, fmt.Errorf("destination already contains %d tasks", len(existing))
+		}
+		return tasks, nil
+	})
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return len(tasks), nil
 }
 
-//Mark a task as done by ID
-func MarkTaskDone(id int) error {
-	tasks, err := LoadTasks()
-	newTasks := make([]Task, 0, len(tasks))
-	for _,t := range tasks{
-		if t.ID !=id {
-			newTasks = append(newTasks, t)
-		} else {
-			// Update the task as Done
-			t.Status = "DONE"
-			t.UpdatedAt = time.Now().Format(time.RFC3339)
-			newTasks = append(newTasks, t)
+// modifyTask applies fn to the task with the given ID, bumps UpdatedAt and
+// returns the modified task.
+func modifyTask(s Store, op string, id int, fn func(t *Task)) (Task, error) {
+	return modifyTaskIn(s, op, id, func(_ []Task, t *Task) error {
+		fn(t)
+		return nil
+	}, nil)
+}
+
+// modifyTaskIn is modifyTask for changes that need to see the other tasks
+// or may be rejected. If after is set it can add to or rewrite the list
+// once the modified task has been validated.
+func modifyTaskIn(s Store, op string, id int, fn func(tasks []Task, t *Task) error, after func(tasks []Task) []Task) (Task, error) {
+	var modified Task
+	err := s.Update(op, func(tasks []Task) ([]Task, error) {
+		i := indexOf(tasks, id)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrTaskNotFound, id)
 		}
-	}
-	data, err := json.MarshalIndent(newTasks, "", "  ")
+		if err := fn(tasks, &tasks[i]); err != nil {
+			return nil, err
+		}
+		if err := normalize(&tasks[i]); err != nil {
+			return nil, err
+		}
+		if err := checkLinks(tasks, tasks[i]); err != nil {
+			return nil, err
+		}
+		tasks[i].UpdatedAt = time.Now().Format(time.RFC3339)
+		modified = tasks[i]
+		if after != nil {
+			tasks = after(tasks)
+		}
+		return tasks, nil
+	})
 	if err != nil {
+		return Task{}, err
+	}
+	return modified, nil
+}
+
+// normalize validates the optional fields of a task and puts them in
+// canonical form.
+func normalize(t *Task) error {
+	if strings.TrimSpace(t.Description) == "" {
+		return fmt.Errorf("description cannot be empty")
+	}
+	var err error
+	if t.Priority, err = ParsePriority(t.Priority); err != nil {
 		return err
 	}
-	err = os.WriteFile(storageFile, data, 0644)
-	if err != nil {
+	if t.Due, err = ParseDue(t.Due); err != nil {
 		return err
 	}
+	t.Tags = NormalizeTags(t.Tags)
+	t.Project = strings.TrimSpace(t.Project)
+	if t.ParentID < 0 {
+		return fmt.Errorf("invalid parent ID %d", t.ParentID)
+	}
+	if len(t.DependsOn) == 0 {
+		t.DependsOn = nil
+	}
+	if len(t.TimeLog) == 0 {
+		t.TimeLog = nil
+	}
+	t.Recurrence = strings.Join(strings.Fields(strings.ToLower(t.Recurrence)), " ")
+	if t.Recurrence != "" {
+		rule, err := ParseRecurrence(t.Recurrence)
+		if err != nil {
+			return err
+		}
+		// A recurring task always has a due date; default to the first
+		// occurrence from today
+		if t.Due == "" {
+			today, _ := parseDate("today")
+			first, err := rule.First(today)
+			if err != nil {
+				return err
+			}
+			t.Due = first.Format(DateLayout)
+		}
+	}
 	return nil
-} 
+}
 
-//Mark a task as done by ID
-func MarkTaskInProgress(id int) error {
-	tasks, err := LoadTasks()
-	newTasks := make([]Task, 0, len(tasks))
-	for _,t := range tasks{
-		if t.ID !=id {
-			newTasks = append(newTasks, t)
-		} else {
-			// Update the task as Done
-			t.Status = "IN-PROGRESS"
-			t.UpdatedAt = time.Now().Format(time.RFC3339)
-			newTasks = append(newTasks, t)
+func nextID(tasks []Task) int {
+	newID := 1
+	for _, t := range tasks {
+		if t.ID >= newID {
+			newID = t.ID + 1
 		}
 	}
-	data, err := json.MarshalIndent(newTasks, "", "  ")
-	if err != nil {
-		return err
-	}
-	err = os.WriteFile(storageFile, data, 0644)
-	if err != nil {
-		return err
+	return newID
+}
+
+func indexOf(tasks []Task, id int) int {
+	for i, t := range tasks {
+		if t.ID == id {
+			return i
+		}
 	}
-	return nil
-} 
\ No newline at end of file
+	return -1
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Store is the persistence backend behind the tasks package.
+// Every mutation goes through Update so that a backend can hold its lock
+// (or transaction) for the whole read-modify-write cycle, and record the
+// change in its append-only journal in the same step.
+type Store interface {
+	// Load returns every task in the store, ordered by ID.
+	Load() ([]Task, error)
+	// Update passes the current tasks to fn and persists the slice it returns,
+	// journaling the difference under op. Nothing is written if fn returns
+	// an error.
+	Update(op string, fn func(tasks []Task) ([]Task, error)) error
+	// UpdateWithJournal is Update for undo and redo: fn also sees the journal
+	// and returns the entry (op, undoes, redoes) to record for its changes.
+	UpdateWithJournal(fn func(tasks []Task, journal []Entry) ([]Task, Entry, error)) error
+	// Journal returns every journal entry, oldest first.
+	Journal() ([]Entry, error)
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// Supported store kinds
+const (
+	StoreJSON   = "json"
+	StoreSQLite = "sqlite"
+)
+
+// Default storage locations, relative to the working directory
+const (
+	DefaultJSONPath   = "tasks.json"
+	DefaultSQLitePath = "tasks.db"
+)
+
+// ErrTaskNotFound is returned when an operation targets an ID that does not exist.
+var ErrTaskNotFound = errors.New("task not found")
+
+// Open returns the store of the given kind. An empty path selects the
+// default file for that kind.
+func Open(kind, path string) (Store, error) {
+	switch kind {
+	case StoreJSON, "":
+		if path == "" {
+			path = DefaultJSONPath
+		}
+		return NewJSONStore(path), nil
+	case StoreSQLite, "sqlite3":
+		if path == "" {
+			path = DefaultSQLitePath
+		}
+		return OpenSQLiteStore(path)
+	default:
+		return nil, fmt.Errorf("unknown store %q (expected %s or %s)", kind, StoreJSON, StoreSQLite)
+	}
+}
@@ -0,0 +1,137 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Task statuses
+const (
+	StatusTodo       = "TO-DO"
+	StatusInProgress = "IN-PROGRESS"
+	StatusDone       = "DONE"
+)
+
+// DateLayout is the format used for due dates
+const DateLayout = "2006-01-02"
+
+// Priorities, lowest first. The index doubles as the sort rank.
+var Priorities = []string{"low", "medium", "high", "urgent"}
+
+// structure of the task storage file
+type Task struct {
+	ID          int        `json:"id"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority,omitempty"`
+	Due         string     `json:"due,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Project     string     `json:"project,omitempty"`
+	ParentID    int        `json:"parent_id,omitempty"`
+	DependsOn   []int      `json:"depends_on,omitempty"`
+	TimeLog     []Interval `json:"time_log,omitempty"`
+	Recurrence  string     `json:"recurrence,omitempty"`
+	SeriesID    int        `json:"series_id,omitempty"`
+	CreatedAt   string     `json:"created_at"`
+	UpdatedAt   string     `json:"updated_at"`
+}
+
+// ParseStatus accepts the stored status or a shorthand such as "todo".
+func ParseStatus(s string) (string, error) {
+	switch strings.ToLower(strings.ReplaceAll(s, "_", "-")) {
+	case "todo", "to-do":
+		return StatusTodo, nil
+	case "in-progress", "inprogress", "progress", "doing":
+		return StatusInProgress, nil
+	case "done":
+		return StatusDone, nil
+	case "blocked":
+		return StatusBlocked, nil
+	}
+	return "", fmt.Errorf("unknown status %q (expected todo, in-progress, blocked or done)", s)
+}
+
+// ParsePriority normalises a priority name. An empty string clears it.
+func ParsePriority(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "none" {
+		return "", nil
+	}
+	if priorityRank(s) == 0 {
+		return "", fmt.Errorf("unknown priority %q (expected one of %s)", s, strings.Join(Priorities, ", "))
+	}
+	return s, nil
+}
+
+// ParseDue normalises a due date given as YYYY-MM-DD, "today" or
+// "tomorrow". An empty string clears it.
+func ParseDue(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "none" {
+		return "", nil
+	}
+	d, err := parseDate(s)
+	if err != nil {
+		return "", err
+	}
+	return d.Format(DateLayout), nil
+}
+
+// NormalizeTags lowercases, trims and de-duplicates tags, keeping their order.
+// Comma separated values are split.
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, raw := range tags {
+		for _, tag := range strings.Split(raw, ",") {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// HasTag reports whether the task carries the given tag.
+func (t Task) HasTag(tag string) bool {
+	for _, have := range t.Tags {
+		if have == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// priorityRank returns 1 for the lowest priority and 0 for none/unknown.
+func priorityRank(p string) int {
+	for i, name := range Priorities {
+		if name == p {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func parseDate(s string) (time.Time, error) {
+	// Dates are compared as calendar days, so anchor "today" at UTC midnight
+	// like time.Parse does for YYYY-MM-DD
+	year, month, day := time.Now().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	switch s {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	d, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD, today or tomorrow)", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,212 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Interval is one stretch of tracked work. End is empty while the timer runs.
+type Interval struct {
+	Start string `json:"start"`
+	End   string `json:"end,omitempty"`
+}
+
+// Report groupings
+const (
+	ReportByTask = "task"
+	ReportByTag  = "tag"
+	ReportByDay  = "day"
+	ReportByWeek = "week"
+)
+
+// ErrNoTimer is returned by StopTimer when nothing is running.
+var ErrNoTimer = errors.New("no timer is running")
+
+// ReportRow is the time tracked for one group in a report.
+type ReportRow struct {
+	Key      string        `json:"key"`
+	Duration time.Duration `json:"-"`
+	Seconds  int64         `json:"seconds"`
+}
+
+// Running reports whether the task has an open interval.
+func (t Task) Running() bool {
+	return len(t.TimeLog) > 0 && t.TimeLog[len(t.TimeLog)-1].End == ""
+}
+
+// RunningTask returns the task whose timer is running, if any.
+func RunningTask(tasks []Task) (Task, bool) {
+	for _, t := range tasks {
+		if t.Running() {
+			return t, true
+		}
+	}
+	return Task{}, false
+}
+
+// StartTimer opens a new interval on the task and moves it to IN-PROGRESS.
+// Only one timer may run at a time.
+func StartTimer(s Store, id int) (Task, error) {
+	return modifyTaskIn(s, "start", id, func(tasks []Task, t *Task) error {
+		if running, ok := RunningTask(tasks); ok {
+			if running.ID == id {
+				return fmt.Errorf("timer already running on task %d", id)
+			}
+			return fmt.Errorf("timer already running on task %d; stop it first", running.ID)
+		}
+		if t.Status == StatusDone {
+			return fmt.Errorf("task %d is already done", id)
+		}
+		t.TimeLog = append(t.TimeLog, Interval{Start: time.Now().Format(time.RFC3339)})
+		t.Status = StatusInProgress
+		return nil
+	}, nil)
+}
+
+// StopTimer closes the running interval. An id of 0 stops whichever timer
+// is running.
+func StopTimer(s Store, id int) (Task, error) {
+	if id == 0 {
+		tasks, err := s.Load()
+		if err != nil {
+			return Task{}, err
+		}
+		running, ok := RunningTask(tasks)
+		if !ok {
+			return Task{}, ErrNoTimer
+		}
+		id = running.ID
+	}
+	return modifyTaskIn(s, "stop", id, func(_ []Task, t *Task) error {
+		if !t.Running() {
+			return fmt.Errorf("%w on task %d", ErrNoTimer, id)
+		}
+		stopTimer(t)
+		return nil
+	}, nil)
+}
+
+// stopTimer closes t's open interval, if it has one
+func stopTimer(t *Task) {
+	if t.Running() {
+		t.TimeLog[len(t.TimeLog)-1].End = time.Now().Format(time.RFC3339)
+	}
+}
+
+// ParseDay parses a YYYY-MM-DD date (or today/yesterday/tomorrow) as local
+// midnight, for report ranges.
+func ParseDay(s string) (time.Time, error) {
+	d, err := parseDate(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.Local), nil
+}
+
+// Report totals the time tracked in [from, to) grouped by task, tag, day or
+// week, along with the overall total. Intervals are clipped to the range and
+// a running timer counts up to now. A task with several tags counts towards
+// each of them, but only once towards the total.
+func Report(tasks []Task, from, to time.Time, by string) ([]ReportRow, time.Duration, error) {
+	switch by {
+	case ReportByTask, ReportByTag, ReportByDay, ReportByWeek:
+	default:
+		return nil, 0, fmt.Errorf("unknown report grouping %q (expected task, tag, day or week)", by)
+	}
+
+	now := time.Now()
+	var total time.Duration
+	totals := map[string]time.Duration{}
+	var order []string
+	add := func(key string, d time.Duration) {
+		if d <= 0 {
+			return
+		}
+		if _, ok := totals[key]; !ok {
+			order = append(order, key)
+		}
+		totals[key] += d
+	}
+
+	for _, t := range tasks {
+		for _, iv := range t.TimeLog {
+			start, end, err := iv.bounds(now)
+			if err != nil {
+				return nil, 0, fmt.Errorf("task %d: %w", t.ID, err)
+			}
+			start, end = clip(start, end, from, to)
+			if !start.Before(end) {
+				continue
+			}
+			total += end.Sub(start)
+			switch by {
+			case ReportByTask:
+				add(fmt.Sprintf("#%d %s", t.ID, t.Description), end.Sub(start))
+			case ReportByTag:
+				if len(t.Tags) == 0 {
+					add("(untagged)", end.Sub(start))
+				}
+				for _, tag := range t.Tags {
+					add(tag, end.Sub(start))
+				}
+			case ReportByDay, ReportByWeek:
+				// Split at local midnight so each day gets its own share
+				for day := startOfDay(start); day.Before(end); day = day.AddDate(0, 0, 1) {
+					s, e := clip(start, end, day, day.AddDate(0, 0, 1))
+					key := day.Format(DateLayout)
+					if by == ReportByWeek {
+						year, week := day.ISOWeek()
+						key = fmt.Sprintf("%d-W%02d", year, week)
+					}
+					add(key, e.Sub(s))
+				}
+			}
+		}
+	}
+
+	// Tasks and tags are listed busiest first, days and weeks in date order
+	if by == ReportByDay || by == ReportByWeek {
+		sort.Strings(order)
+	} else {
+		sort.SliceStable(order, func(i, j int) bool { return totals[order[i]] > totals[order[j]] })
+	}
+	rows := make([]ReportRow, 0, len(order))
+	for _, key := range order {
+		d := totals[key].Round(time.Second)
+		rows = append(rows, ReportRow{Key: key, Duration: d, Seconds: int64(d.Seconds())})
+	}
+	return rows, total.Round(time.Second), nil
+}
+
+// bounds parses the interval, treating an open end as now
+func (iv Interval) bounds(now time.Time) (time.Time, time.Time, error) {
+	start, err := time.Parse(time.RFC3339, iv.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid interval start %q", iv.Start)
+	}
+	if iv.End == "" {
+		return start, now, nil
+	}
+	end, err := time.Parse(time.RFC3339, iv.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid interval end %q", iv.End)
+	}
+	return start, end, nil
+}
+
+func clip(start, end, from, to time.Time) (time.Time, time.Time) {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return start, end
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
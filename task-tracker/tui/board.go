@@ -0,0 +1,338 @@
+// Package tui is the interactive kanban board behind the tui command. It
+// works directly on a tasks.Store, so every change it makes is journaled
+// like the equivalent CLI command.
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"task-tracker/tasks"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Run opens the board full screen and blocks until the user quits.
+func Run(store tasks.Store) error {
+	m := newModel(store)
+	if err := m.reload(); err != nil {
+		return err
+	}
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+// Columns, in board order
+var columns = []string{tasks.StatusTodo, tasks.StatusInProgress, tasks.StatusDone}
+
+type mode int
+
+const (
+	modeBoard mode = iota
+	modeAdd
+	modeEdit
+	modeFilter
+	modeConfirmDelete
+)
+
+// card is a task as shown on the board
+type card struct {
+	tasks.Task
+	blockedBy []int
+}
+
+type model struct {
+	store tasks.Store
+
+	cards  [3][]card
+	col    int
+	row    [3]int
+	offset [3]int
+
+	filter string
+	query  tasks.Query
+
+	mode    mode
+	input   textinput.Model
+	message string
+	isError bool
+	help    bool
+
+	width, height int
+}
+
+func newModel(store tasks.Store) *model {
+	input := textinput.New()
+	input.CharLimit = 500
+	return &model{store: store, input: input, width: 100, height: 30}
+}
+
+// reload reads the store and rebuilds the columns, keeping the cursor on
+// the selected task where possible
+func (m *model) reload() error {
+	selected := 0
+	if c, ok := m.selected(); ok {
+		selected = c.ID
+	}
+	all, err := tasks.LoadTasks(m.store)
+	if err != nil {
+		return err
+	}
+	resolved := tasks.ResolveBlocked(all)
+	m.cards = [3][]card{}
+	for i, t := range all {
+		if !m.query.Match(resolved[i]) {
+			continue
+		}
+		col := slices.Index(columns, t.Status)
+		if col < 0 {
+			col = 0
+		}
+		c := card{Task: t}
+		if resolved[i].Status == tasks.StatusBlocked {
+			c.blockedBy = tasks.BlockedBy(all, t)
+		}
+		m.cards[col] = append(m.cards[col], c)
+	}
+	for col := range m.cards {
+		m.row[col] = min(m.row[col], max(len(m.cards[col])-1, 0))
+	}
+	if selected != 0 {
+		m.selectID(selected)
+	}
+	return nil
+}
+
+func (m *model) selected() (card, bool) {
+	cards := m.cards[m.col]
+	if len(cards) == 0 {
+		return card{}, false
+	}
+	return cards[m.row[m.col]], true
+}
+
+func (m *model) selectID(id int) {
+	for col, cards := range m.cards {
+		for row, c := range cards {
+			if c.ID == id {
+				m.col, m.row[col] = col, row
+				return
+			}
+		}
+	}
+}
+
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeBoard:
+			return m.updateBoard(msg)
+		case modeConfirmDelete:
+			return m.updateConfirm(msg)
+		default:
+			return m.updateInput(msg)
+		}
+	}
+	return m, nil
+}
+
+func (m *model) updateBoard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.message, m.isError = "", false
+	c, ok := m.selected()
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "?":
+		m.help = !m.help
+	case "left", "h":
+		m.col = max(m.col-1, 0)
+	case "right", "l":
+		m.col = min(m.col+1, len(columns)-1)
+	case "up", "k":
+		m.row[m.col] = max(m.row[m.col]-1, 0)
+	case "down", "j":
+		m.row[m.col] = min(m.row[m.col]+1, max(len(m.cards[m.col])-1, 0))
+	case "g", "home":
+		m.row[m.col] = 0
+	case "G", "end":
+		m.row[m.col] = max(len(m.cards[m.col])-1, 0)
+	case "shift+right", "L", ">", "]":
+		if ok {
+			m.move(c, m.col+1)
+		}
+	case "shift+left", "H", "<", "[":
+		if ok {
+			m.move(c, m.col-1)
+		}
+	case "a":
+		m.startInput(modeAdd, "New task: ", "")
+	case "e", "enter":
+		if ok {
+			m.startInput(modeEdit, fmt.Sprintf("Edit #%d: ", c.ID), c.Description)
+		}
+	case "/":
+		m.startInput(modeFilter, "Filter: ", m.filter)
+	case "p":
+		if ok {
+			m.apply(tasks.UpdateTask(m.store, c.ID, func(t *tasks.Task) {
+				t.Priority = nextPriority(t.Priority)
+			}))
+		}
+	case "s":
+		if ok {
+			if c.Running() {
+				m.apply(tasks.StopTimer(m.store, c.ID))
+			} else {
+				m.apply(tasks.StartTimer(m.store, c.ID))
+			}
+		}
+	case "x", "d", "delete":
+		if ok {
+			m.mode = modeConfirmDelete
+		}
+	case "u":
+		e, err := tasks.Undo(m.store)
+		m.rewind("Undid", e, err)
+	case "ctrl+r":
+		e, err := tasks.Redo(m.store)
+		m.rewind("Redid", e, err)
+	case "r":
+		m.apply(tasks.Task{}, m.reload())
+	}
+	return m, nil
+}
+
+// move shifts a card to the given column through the matching CLI operation
+func (m *model) move(c card, col int) {
+	if col < 0 || col >= len(columns) || col == m.col {
+		return
+	}
+	var err error
+	var next *tasks.Task
+	switch columns[col] {
+	case tasks.StatusTodo:
+		_, err = tasks.MarkTaskTodo(m.store, c.ID)
+	case tasks.StatusInProgress:
+		_, err = tasks.MarkTaskInProgress(m.store, c.ID)
+	case tasks.StatusDone:
+		_, next, err = tasks.MarkTaskDone(m.store, c.ID, false)
+	}
+	m.apply(c.Task, err)
+	if next != nil {
+		m.message = fmt.Sprintf("Next occurrence #%d due %s", next.ID, next.Due)
+	}
+}
+
+func (m *model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = modeBoard
+	if c, ok := m.selected(); ok && (msg.String() == "y" || msg.String() == "Y") {
+		deleted, err := tasks.DeleteTask(m.store, c.ID)
+		m.apply(deleted, err)
+		if err == nil {
+			m.message = fmt.Sprintf("Deleted #%d (u to undo)", deleted.ID)
+		}
+	}
+	return m, nil
+}
+
+func (m *model) startInput(md mode, prompt, value string) {
+	m.mode = md
+	m.input.Prompt = prompt
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+}
+
+func (m *model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = modeBoard
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		md := m.mode
+		m.mode = modeBoard
+		m.input.Blur()
+		m.submit(md, value)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *model) submit(md mode, value string) {
+	switch md {
+	case modeAdd:
+		if value == "" {
+			return
+		}
+		m.apply(tasks.AddTask(m.store, tasks.Task{Description: value}))
+	case modeEdit:
+		c, ok := m.selected()
+		if !ok || value == "" || value == c.Description {
+			return
+		}
+		m.apply(tasks.UpdateTask(m.store, c.ID, func(t *tasks.Task) {
+			t.Description = value
+		}))
+	case modeFilter:
+		q, err := tasks.ParseQuery(value)
+		if err != nil {
+			m.message, m.isError = err.Error(), true
+			return
+		}
+		m.filter, m.query = value, q
+		m.apply(tasks.Task{}, m.reload())
+	}
+}
+
+// apply reports the outcome of an operation, reloads the board and keeps
+// the affected task selected
+func (m *model) apply(t tasks.Task, err error) {
+	if err != nil {
+		m.message, m.isError = err.Error(), true
+		return
+	}
+	if reloadErr := m.reload(); reloadErr != nil {
+		m.message, m.isError = reloadErr.Error(), true
+		return
+	}
+	if t.ID != 0 {
+		m.selectID(t.ID)
+	}
+}
+
+// rewind reports an undo or redo; an empty stack is not an error
+func (m *model) rewind(verb string, e tasks.Entry, err error) {
+	if errors.Is(err, tasks.ErrNothingToUndo) || errors.Is(err, tasks.ErrNothingToRedo) {
+		m.message = err.Error()
+		return
+	}
+	m.apply(tasks.Task{}, err)
+	if err == nil {
+		m.message = fmt.Sprintf("%s #%d %s", verb, e.Seq, e.Op)
+	}
+}
+
+func nextPriority(p string) string {
+	i := slices.Index(tasks.Priorities, p)
+	if i+1 >= len(tasks.Priorities) {
+		return ""
+	}
+	return tasks.Priorities[i+1]
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	columnStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240")).Padding(0, 1)
+	activeStyle   = columnStyle.BorderForeground(lipgloss.Color("63"))
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	metaStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	blockedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+const shortHelp = "←/→ column  ↑/↓ select  [/] move  a add  e edit  p priority  s timer  x delete  / filter  u undo  ^r redo  ? help  q quit"
+
+const fullHelp = `h/l or ←/→   switch column          j/k or ↑/↓   select task
+H/L or [/]   move task left/right   g/G          first/last task
+a            add a task             e or enter   edit description
+p            cycle priority         s            start/stop timer
+x or d       delete (asks first)    /            filter, e.g. tag:backend priority>=high
+u            undo                   ctrl+r       redo
+r            reload                 q or esc     quit`
+
+func (m *model) View() string {
+	header := titleStyle.Render("Task Tracker")
+	if m.filter != "" {
+		header += metaStyle.Render("  filter: " + m.filter)
+	}
+
+	footer := m.footer()
+	bodyHeight := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	// Border (2) and column title (1) take three lines; each card takes two
+	visible := max((bodyHeight-3)/2, 1)
+	colWidth := max(m.width/len(columns)-2, 16)
+
+	cols := make([]string, len(columns))
+	for i := range columns {
+		cols[i] = m.renderColumn(i, colWidth, bodyHeight-2, visible)
+	}
+	body := lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
+func (m *model) renderColumn(col, width, height, visible int) string {
+	cards := m.cards[col]
+	inner := width - 2 // padding
+
+	// Scroll so the selected card stays visible
+	row := m.row[col]
+	if row < m.offset[col] {
+		m.offset[col] = row
+	}
+	if row >= m.offset[col]+visible {
+		m.offset[col] = row - visible + 1
+	}
+	m.offset[col] = max(min(m.offset[col], len(cards)-visible), 0)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("%s (%d)", columns[col], len(cards)))}
+	end := min(m.offset[col]+visible, len(cards))
+	for i := m.offset[col]; i < end; i++ {
+		c := cards[i]
+		title := truncate(fmt.Sprintf("#%d %s", c.ID, c.Description), inner)
+		meta := cardMeta(c, inner)
+		if col == m.col && i == row {
+			title = selectedStyle.Render(pad(title, inner))
+		}
+		lines = append(lines, title, meta)
+	}
+	if len(cards) == 0 {
+		lines = append(lines, metaStyle.Render("(empty)"))
+	}
+
+	style := columnStyle
+	if col == m.col {
+		style = activeStyle
+	}
+	return style.Width(width).Height(max(height, len(lines))).Render(strings.Join(lines, "\n"))
+}
+
+// cardMeta is the second line of a card: blocked marker, timer, priority,
+// due date and tags
+func cardMeta(c card, width int) string {
+	var parts []string
+	if c.Running() {
+		parts = append(parts, "⏱")
+	}
+	if c.Priority != "" {
+		parts = append(parts, c.Priority)
+	}
+	if c.Due != "" {
+		parts = append(parts, "due "+c.Due)
+	}
+	if c.Recurrence != "" {
+		parts = append(parts, "↻")
+	}
+	for _, tag := range c.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	meta := metaStyle.Render(truncate(strings.Join(parts, " "), width))
+	if len(c.blockedBy) > 0 {
+		blocked := "BLOCKED by " + joinIDs(c.blockedBy)
+		meta = blockedStyle.Render(truncate(blocked, width))
+	}
+	return meta
+}
+
+func (m *model) footer() string {
+	var status string
+	switch m.mode {
+	case modeAdd, modeEdit, modeFilter:
+		status = m.input.View()
+	case modeConfirmDelete:
+		if c, ok := m.selected(); ok {
+			status = errorStyle.Render(fmt.Sprintf("Delete #%d %q? (y/n)", c.ID, c.Description))
+		}
+	default:
+		if m.isError {
+			status = errorStyle.Render(m.message)
+		} else {
+			status = m.message
+		}
+	}
+	help := helpStyle.Render(truncate(shortHelp, m.width))
+	if m.help {
+		help = helpStyle.Render(fullHelp)
+	}
+	return status + "\n" + help
+}
+
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprint(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+// truncate shortens s to width runes, marking the cut with an ellipsis
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(r[:width])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+func pad(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"tmdb/tmdbapi"
+
+	"github.com/spf13/cobra"
+)
+
+// discoverCmd represents the discover command
+var discoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Find movies or TV shows by genre, year, rating and more",
+	Long: `Find movies or TV shows with TMDB's discover filters.
+Genres can be given by name or ID; run without a match to see the names.
+Example usage:
+tmdb discover --genre horror --year-from 1970 --year-to 1989 --sort rating --min-votes 200
+tmdb discover --original-language ko --min-rating 7.5
+tmdb discover --type tv --genre animation --sort release
+tmdb discover --max-runtime 90 --region GB --language en-GB`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mediaType, _ := cmd.Flags().GetString("type")
+		if mediaType != tmdbapi.MediaMovie && mediaType != tmdbapi.MediaTV {
+			fmt.Println("Unknown discover type. Please use one of: movie, tv.")
+			os.Exit(1)
+		}
+		filters := getDiscoverOptions(cmd)
+		opts := getListOptions(cmd)
+		client := newClient()
+
+		sortBy, _ := cmd.Flags().GetString("sort")
+		var err error
+		filters.SortBy, err = tmdbapi.SortOrder(mediaType, sortBy)
+		exitOnError(err)
+		if genres, _ := cmd.Flags().GetStringSlice("genre"); len(genres) > 0 {
+			list, err := client.MovieGenres()
+			if mediaType == tmdbapi.MediaTV {
+				list, err = client.TVGenres()
+			}
+			exitOnError(err)
+			filters.Genres, err = tmdbapi.GenreIDs(genres, list)
+			exitOnError(err)
+		}
+
+		if mediaType == tmdbapi.MediaTV {
+			shows, err := tmdbapi.Collect(opts.Page, opts.Limit, func(page int) (*tmdbapi.Page[tmdbapi.TVShow], error) {
+				return client.DiscoverTV(filters, page)
+			})
+			exitOnError(err)
+			if opts.Output == "json" {
+				printJSON(shows)
+				return
+			}
+			if len(shows) == 0 {
+				fmt.Println("No TV shows found.")
+				return
+			}
+			printRows(tvRows(shows, &genreLookup{client: client}))
+			return
+		}
+
+		movies, err := tmdbapi.Collect(opts.Page, opts.Limit, func(page int) (*tmdbapi.Page[tmdbapi.Movie], error) {
+			return client.DiscoverMovies(filters, page)
+		})
+		exitOnError(err)
+		if opts.Output == "json" {
+			printJSON(movies)
+			return
+		}
+		if len(movies) == 0 {
+			fmt.Println("No movies found.")
+			return
+		}
+		printRows(movieRows(movies, &genreLookup{client: client}))
+	},
+}
+
+// getDiscoverOptions reads and checks the filter flags, except for genres
+// and sort order, which depend on the media type
+func getDiscoverOptions(cmd *cobra.Command) tmdbapi.DiscoverOptions {
+	var opts tmdbapi.DiscoverOptions
+	opts.YearFrom, _ = cmd.Flags().GetInt("year-from")
+	opts.YearTo, _ = cmd.Flags().GetInt("year-to")
+	if year, _ := cmd.Flags().GetInt("year"); year != 0 {
+		opts.YearFrom, opts.YearTo = year, year
+	}
+	opts.MinRating, _ = cmd.Flags().GetFloat64("min-rating")
+	opts.MinVotes, _ = cmd.Flags().GetInt("min-votes")
+	opts.MinRuntime, _ = cmd.Flags().GetInt("min-runtime")
+	opts.MaxRuntime, _ = cmd.Flags().GetInt("max-runtime")
+	opts.OriginalLanguage, _ = cmd.Flags().GetString("original-language")
+
+	if opts.YearFrom < 0 || opts.YearTo < 0 || (opts.YearTo > 0 && opts.YearFrom > opts.YearTo) {
+		exitOnError(fmt.Errorf("invalid year range %d-%d", opts.YearFrom, opts.YearTo))
+	}
+	if opts.MinRating < 0 || opts.MinRating > 10 {
+		exitOnError(fmt.Errorf("--min-rating must be between 0 and 10"))
+	}
+	if opts.MinVotes < 0 {
+		exitOnError(fmt.Errorf("--min-votes must not be negative"))
+	}
+	if opts.MinRuntime < 0 || opts.MaxRuntime < 0 || (opts.MaxRuntime > 0 && opts.MinRuntime > opts.MaxRuntime) {
+		exitOnError(fmt.Errorf("invalid runtime range %d-%d minutes", opts.MinRuntime, opts.MaxRuntime))
+	}
+	if opts.OriginalLanguage != "" && !languagePattern.MatchString(opts.OriginalLanguage) {
+		exitOnError(fmt.Errorf("invalid --original-language %q (use a language code such as ja or ko)", opts.OriginalLanguage))
+	}
+	return opts
+}
+
+func init() {
+	rootCmd.AddCommand(discoverCmd)
+	discoverCmd.Flags().StringP("type", "t", tmdbapi.MediaMovie, "What to discover (movie, tv)")
+	discoverCmd.Flags().StringSliceP("genre", "g", nil, "Only show titles in all of these genres, by name or ID")
+	discoverCmd.Flags().Int("year", 0, "Only show titles released in this year")
+	discoverCmd.Flags().Int("year-from", 0, "Only show titles released in or after this year")
+	discoverCmd.Flags().Int("year-to", 0, "Only show titles released in or before this year")
+	discoverCmd.Flags().Float64("min-rating", 0, "Only show titles rated at least this high (0-10)")
+	discoverCmd.Flags().Int("min-votes", 0, "Only show titles with at least this many votes")
+	discoverCmd.Flags().Int("min-runtime", 0, "Only show titles at least this many minutes long")
+	discoverCmd.Flags().Int("max-runtime", 0, "Only show titles at most this many minutes long")
+	discoverCmd.Flags().String("original-language", "", "Only show titles originally in this language, e.g. ja")
+	discoverCmd.Flags().String("sort", "popularity", "Sort order (popularity, rating, votes, release, revenue, title) or a TMDB sort order such as vote_count.asc")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"tmdb/tmdbapi"
+
+	"github.com/spf13/cobra"
+)
+
+// movieCmd represents the movie command
+var movieCmd = &cobra.Command{
+	Use:   "movie <id>",
+	Short: "Show the details of a movie",
+	Long: `Show the details of a movie: rating, genres, runtime, director and cast.
+Example usage:
+tmdb movie 550`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := parseID(args[0])
+		opts := getListOptions(cmd)
+		movie, err := newClient().Movie(id)
+		exitOnError(err)
+		if opts.Output == "json" {
+			printJSON(movie)
+			return
+		}
+
+		printRows([]row{{ID: movie.ID, Title: movie.Title, Year: movie.Year(), Rating: movie.VoteAverage, Votes: movie.VoteCount, Genres: genreNames(movie.Genres)}})
+		fmt.Println()
+		if movie.Tagline != "" {
+			fmt.Println(movie.Tagline)
+			fmt.Println()
+		}
+		printField("Runtime", formatRuntime(movie.Runtime))
+		printField("Released", movie.ReleaseDate)
+		printField("Director", crewNames(movie.Credits.Directors()))
+		printField("Status", movie.Status)
+		if movie.OriginalTitle != movie.Title {
+			printField("Original", movie.OriginalTitle)
+		}
+		if movie.Overview != "" {
+			fmt.Println()
+			fmt.Println(movie.Overview)
+		}
+		printCast(movie.Credits.Cast)
+	},
+}
+
+// genreNames lists the names of genres
+func genreNames(genres []tmdbapi.Genre) []string {
+	names := make([]string, len(genres))
+	for i, g := range genres {
+		names[i] = g.Name
+	}
+	return names
+}
+
+func init() {
+	rootCmd.AddCommand(movieCmd)
+}
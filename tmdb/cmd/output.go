@@ -0,0 +1,315 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"tmdb/tmdbapi"
+
+	"github.com/spf13/cobra"
+)
+
+// row is one line of a listing table
+type row struct {
+	ID     int
+	Type   string // movie, tv or person; only shown for mixed listings
+	Title  string
+	Year   string
+	Rating float64
+	Votes  int
+	Genres []string
+}
+
+// listOptions are the --output, --page and --limit flags
+type listOptions struct {
+	Output string
+	Page   int
+	Limit  int
+}
+
+// apiClient is the client of the running command, for reportCacheAge
+var apiClient *tmdbapi.Client
+
+// newClient creates a TMDB client from the environment and the cache
+// flags, or exits
+func newClient() *tmdbapi.Client {
+	client, err := tmdbapi.NewClientFromEnv()
+	if err == tmdbapi.ErrNoAPIKey && offline {
+		// No key is needed to read the cache
+		client, err = tmdbapi.NewClient(""), nil
+	}
+	if err != nil {
+		fmt.Println("Please set the TMDB_API_KEY environment variable.")
+		os.Exit(1)
+	}
+	if !noCache {
+		cache, err := tmdbapi.NewCache()
+		exitOnError(err)
+		client.Cache = cache
+	}
+	client.Offline = offline
+	if language != "" {
+		if !languagePattern.MatchString(language) {
+			exitOnError(fmt.Errorf("invalid --language %q (use a language code such as en, de-DE or pt-BR)", language))
+		}
+		client.Language = language
+	}
+	if region != "" {
+		region = strings.ToUpper(region)
+		if !regionPattern.MatchString(region) {
+			exitOnError(fmt.Errorf("invalid --region %q (use a two-letter country code such as US or GB)", region))
+		}
+		client.Region = region
+	}
+	apiClient = client
+	return client
+}
+
+var (
+	// languagePattern matches ISO 639-1 codes with an optional country
+	languagePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+	// regionPattern matches ISO 3166-1 country codes
+	regionPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// reportCacheAge tells how old the data is when it did not come fresh from
+// TMDB: always in offline mode, and when TMDB could not be reached
+func reportCacheAge() {
+	if apiClient == nil {
+		return
+	}
+	usage := apiClient.CacheUsage()
+	if usage.Oldest.IsZero() || !(offline || usage.Stale) {
+		return
+	}
+	age := formatAge(time.Since(usage.Oldest))
+	if offline {
+		fmt.Fprintf(os.Stderr, "Offline: showing cached data from %s\n", age)
+	} else {
+		fmt.Fprintf(os.Stderr, "Could not reach TMDB: showing cached data from %s\n", age)
+	}
+}
+
+// formatAge formats a duration as e.g. "3 hours ago"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute") + " ago"
+	case d < 48*time.Hour:
+		return plural(int(d/time.Hour), "hour") + " ago"
+	}
+	return plural(int(d/(24*time.Hour)), "day") + " ago"
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// exitOnError prints err and exits if it is not nil
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+}
+
+// getListOptions reads and checks the output and paging flags
+func getListOptions(cmd *cobra.Command) listOptions {
+	var opts listOptions
+	opts.Output, _ = cmd.Flags().GetString("output")
+	opts.Page, _ = cmd.Flags().GetInt("page")
+	opts.Limit, _ = cmd.Flags().GetInt("limit")
+	if opts.Output != "table" && opts.Output != "json" {
+		exitOnError(fmt.Errorf("unknown output format %q (use table or json)", opts.Output))
+	}
+	if opts.Page < 1 || opts.Page > tmdbapi.MaxPage {
+		exitOnError(fmt.Errorf("--page must be between 1 and %d", tmdbapi.MaxPage))
+	}
+	if opts.Limit < 1 {
+		exitOnError(fmt.Errorf("--limit must be at least 1"))
+	}
+	return opts
+}
+
+// pageOf picks --limit items of a local list starting at --page. Pages
+// are TMDB-sized, so --page skips the same number of results here as it
+// does for lists fetched from TMDB.
+func pageOf[T any](items []T, opts listOptions) []T {
+	start := (opts.Page - 1) * tmdbapi.PageSize
+	if start >= len(items) {
+		return nil
+	}
+	return items[start:min(start+opts.Limit, len(items))]
+}
+
+// printJSON writes v as indented JSON
+func printJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	exitOnError(enc.Encode(v))
+}
+
+// printRows prints a table of movies, shows or people
+func printRows(rows []row) {
+	if len(rows) == 0 {
+		fmt.Println("No results found.")
+		return
+	}
+	mixed := false
+	for _, r := range rows {
+		if r.Type != "" {
+			mixed = true
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if mixed {
+		fmt.Fprint(w, "TYPE\t")
+	}
+	fmt.Fprintln(w, "ID\tTITLE\tYEAR\tRATING\tVOTES\tGENRES")
+	for _, r := range rows {
+		if mixed {
+			fmt.Fprintf(w, "%s\t", r.Type)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			r.ID, r.Title, orDash(r.Year), formatRating(r.Rating, r.Votes), formatVotes(r.Votes), orDash(strings.Join(r.Genres, ", ")))
+	}
+	w.Flush()
+}
+
+// genreLookup turns genre IDs into names, fetching each genre list once.
+// Genres are nice to have, so a failed lookup just leaves them out.
+type genreLookup struct {
+	client *tmdbapi.Client
+	movie  []tmdbapi.Genre
+	tv     []tmdbapi.Genre
+}
+
+func (g *genreLookup) names(mediaType string, ids []int) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	if mediaType == tmdbapi.MediaTV {
+		if g.tv == nil {
+			g.tv, _ = g.client.TVGenres()
+		}
+		return tmdbapi.GenreNames(ids, g.tv)
+	}
+	if g.movie == nil {
+		g.movie, _ = g.client.MovieGenres()
+	}
+	return tmdbapi.GenreNames(ids, g.movie)
+}
+
+func movieRows(movies []tmdbapi.Movie, genres *genreLookup) []row {
+	rows := make([]row, len(movies))
+	for i, m := range movies {
+		rows[i] = row{ID: m.ID, Title: m.Title, Year: m.Year(), Rating: m.VoteAverage, Votes: m.VoteCount, Genres: genres.names(tmdbapi.MediaMovie, m.GenreIDs)}
+	}
+	return rows
+}
+
+func tvRows(shows []tmdbapi.TVShow, genres *genreLookup) []row {
+	rows := make([]row, len(shows))
+	for i, s := range shows {
+		rows[i] = row{ID: s.ID, Title: s.Name, Year: s.Year(), Rating: s.VoteAverage, Votes: s.VoteCount, Genres: genres.names(tmdbapi.MediaTV, s.GenreIDs)}
+	}
+	return rows
+}
+
+// printField prints one "Label: value" line of a details view, skipping
+// empty values
+func printField(label, value string) {
+	if value != "" && value != "0" {
+		fmt.Printf("%-12s%s\n", label+":", value)
+	}
+}
+
+func formatRating(rating float64, votes int) string {
+	if votes == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(rating, 'f', 1, 64)
+}
+
+// formatVotes formats a vote count with thousands separators
+func formatVotes(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0 && s[i-1] != '-'; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// formatRuntime formats minutes as e.g. 2h 19m
+func formatRuntime(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// parseID parses a TMDB ID argument, or exits
+func parseID(arg string) int {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id <= 0 {
+		exitOnError(fmt.Errorf("invalid TMDB ID %q", arg))
+	}
+	return id
+}
+
+// castShown is how many cast members the details views list
+const castShown = 10
+
+// printCast prints the top-billed cast of a movie or show
+func printCast(cast []tmdbapi.CastMember) {
+	if len(cast) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Cast:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, member := range cast {
+		if i == castShown {
+			break
+		}
+		fmt.Fprintf(w, "  %s\t%s\n", member.Name, member.Character)
+	}
+	w.Flush()
+}
+
+// crewNames joins the names of crew members
+func crewNames(crew []tmdbapi.CrewMember) string {
+	names := make([]string, len(crew))
+	for i, member := range crew {
+		names[i] = member.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"tmdb/tmdbapi"
+
+	"github.com/spf13/cobra"
+)
+
+// personCmd represents the person command
+var personCmd = &cobra.Command{
+	Use:   "person <id>",
+	Short: "Show a person and the movies and shows they are known for",
+	Long: `Show a person's details and their best-known movies and TV shows.
+Use --limit to show more or fewer credits.
+Example usage:
+tmdb person 287
+tmdb person 287 --limit 50`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := parseID(args[0])
+		opts := getListOptions(cmd)
+		client := newClient()
+		person, err := client.Person(id)
+		exitOnError(err)
+		if opts.Output == "json" {
+			printJSON(person)
+			return
+		}
+
+		fmt.Println(person.Name)
+		fmt.Println()
+		printField("Known for", person.KnownForDepartment)
+		printField("Born", joinNonEmpty(" in ", person.Birthday, person.PlaceOfBirth))
+		printField("Died", person.Deathday)
+		if person.Biography != "" {
+			fmt.Println()
+			fmt.Println(person.Biography)
+		}
+
+		credits := topCredits(person.Credits, opts.Limit)
+		if len(credits) == 0 {
+			return
+		}
+		fmt.Println()
+		genres := &genreLookup{client: client}
+		rows := make([]row, len(credits))
+		for i, c := range credits {
+			rows[i] = row{ID: c.ID, Type: c.MediaType, Title: c.DisplayTitle(), Year: c.Year(), Rating: c.VoteAverage, Votes: c.VoteCount, Genres: genres.names(c.MediaType, c.GenreIDs)}
+		}
+		printRows(rows)
+	},
+}
+
+// topCredits merges cast and crew credits, lists each title once and keeps
+// the limit most voted
+func topCredits(credits tmdbapi.PersonCredits, limit int) []tmdbapi.Credit {
+	seen := make(map[string]bool)
+	var merged []tmdbapi.Credit
+	for _, c := range append(credits.Cast, credits.Crew...) {
+		key := fmt.Sprintf("%s/%d", c.MediaType, c.ID)
+		if !seen[key] {
+			seen[key] = true
+			merged = append(merged, c)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].VoteCount > merged[j].VoteCount
+	})
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
+
+// joinNonEmpty joins the non-empty values with sep
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+func init() {
+	rootCmd.AddCommand(personCmd)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"tmdb/library"
+
+	"github.com/spf13/cobra"
+)
+
+// rateCmd represents the rate command
+var rateCmd = &cobra.Command{
+	Use:   "rate [<id> <score>]",
+	Short: "Rate a movie you have seen, or list your ratings",
+	Long: `Rate a movie from 0.5 to 10 in steps of 0.5. Rating a movie marks it as
+seen and takes it off the watchlist. Without arguments, lists your ratings.
+Example usage:
+tmdb rate 550 9
+tmdb rate 13 7.5
+tmdb rate
+tmdb rate --limit 10 --page 2`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return fmt.Errorf("expected <id> <score>, or no arguments to list ratings")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		lib := openLibrary()
+		if len(args) == 0 {
+			opts := getListOptions(cmd)
+			rated := lib.Rated()
+			movies := pageOf(rated, opts)
+			if opts.Output == "json" {
+				printJSON(movies)
+				return
+			}
+			if len(rated) == 0 {
+				fmt.Println("No ratings yet. Rate a movie with: tmdb rate <id> <score>")
+				return
+			}
+			if len(movies) == 0 {
+				fmt.Printf("No ratings on page %d.\n", opts.Page)
+				return
+			}
+			printLibrary(movies, true)
+			return
+		}
+
+		id := parseID(args[0])
+		score, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			err = fmt.Errorf("invalid score %q", args[1])
+		} else {
+			err = library.CheckScore(score)
+		}
+		exitOnError(err)
+
+		wasOnWatchlist := false
+		if m := lib.Get(id); m != nil {
+			wasOnWatchlist = m.Watchlist
+		}
+		details, err := newClient().Movie(id)
+		exitOnError(err)
+		m, err := lib.Rate(details, score)
+		exitOnError(err)
+		exitOnError(lib.Save())
+		fmt.Printf("Rated %s %g/10\n", titleYear(m.Title, m.Year), m.Score)
+		if wasOnWatchlist {
+			fmt.Println("Removed it from the watchlist")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rateCmd)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"tmdb/tmdbapi"
+
+	"github.com/spf13/cobra"
+)
+
+// recommendCmd represents the recommend command
+var recommendCmd = &cobra.Command{
+	Use:   "recommend",
+	Short: "Recommend movies based on your ratings",
+	Long: `Recommend movies using TMDB's recommendations for the movies you rated
+highest. Movies you have already seen are left out.
+Example usage:
+tmdb recommend
+tmdb recommend --min-score 8 --seeds 10 --limit 30
+tmdb recommend --page 2`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		minScore, _ := cmd.Flags().GetFloat64("min-score")
+		n, _ := cmd.Flags().GetInt("seeds")
+		if n < 1 {
+			exitOnError(fmt.Errorf("--seeds must be at least 1"))
+		}
+		opts := getListOptions(cmd)
+		lib := openLibrary()
+
+		seeds := lib.Seeds(minScore, n)
+		if len(seeds) == 0 {
+			fmt.Printf("No movies rated %g or higher yet. Rate some with: tmdb rate <id> <score>\n", minScore)
+			return
+		}
+		client := newClient()
+		recs, err := lib.Recommend(client, seeds)
+		exitOnError(err)
+		recs = pageOf(recs, opts)
+		if opts.Output == "json" {
+			printJSON(recs)
+			return
+		}
+		if len(recs) == 0 {
+			fmt.Println("No recommendations found.")
+			return
+		}
+
+		genres := &genreLookup{client: client}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTITLE\tYEAR\tRATING\tVOTES\tGENRES\tBECAUSE YOU LIKED")
+		for _, r := range recs {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				r.ID, r.Title, orDash(r.Year()), formatRating(r.VoteAverage, r.VoteCount), formatVotes(r.VoteCount),
+				orDash(strings.Join(genres.names(tmdbapi.MediaMovie, r.GenreIDs), ", ")), strings.Join(r.Because, ", "))
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recommendCmd)
+	recommendCmd.Flags().Float64("min-score", 7, "Only use movies you rated at least this high")
+	recommendCmd.Flags().Int("seeds", 5, "Number of rated movies to base recommendations on")
+}
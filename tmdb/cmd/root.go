@@ -11,7 +11,13 @@
 		"github.com/spf13/cobra"
 	)
 
-	var movieType string
+	var (
+		movieType string
+		offline   bool
+		noCache   bool
+		language  string
+		region    string
+	)
 
 	// rootCmd represents the base command when called without any subcommands
 	var rootCmd = &cobra.Command{
@@ -19,6 +25,7 @@
 		Short: "A CLI tool for The Movie Database (TMDB)",
 		Long: `A command line interface for fetching movie data from TMDB.
 	Supports different movie categories like playing, popular, top rated, and upcoming.
+	Use the subcommands to search and look up movies, TV shows and people.
 	Example usage:
 	tmdb --type playing
 	tmdb --type popular
@@ -26,25 +33,41 @@
 	tmdb --type upcoming`,
 
 		Run: func(cmd *cobra.Command, args []string) {
-			switch movieType {
-			case "playing":
-				tmdbapi.Playing() // Call the Playing function from tmdbapi package
-				// Fetch and display currently playing movies
-				fmt.Println("Fetching currently playing movies...")
-			case "popular":
-				// Fetch and display popular movies
-				tmdbapi.Popular() // Assuming you have a Popular function in tmdbapi
-			case "top_rated":
-				// Fetch and display top-rated movies
-				tmdbapi.TopRated() // Assuming you have a TopRated function in tmdbapi
-			case "upcoming":
-				// Fetch and display upcoming movies
-				tmdbapi.Upcoming() // Assuming you have an Upcoming function in tmdbapi
-			default:
+			lists := map[string]struct {
+				list  tmdbapi.MovieList
+				label string
+			}{
+				"playing":   {tmdbapi.NowPlaying, "Currently Playing"},
+				"popular":   {tmdbapi.Popular, "Popular"},
+				"top_rated": {tmdbapi.TopRated, "Top Rated"},
+				"upcoming":  {tmdbapi.Upcoming, "Upcoming"},
+			}
+			selected, ok := lists[movieType]
+			if !ok {
 				// Handle unknown movie types
 				fmt.Println("Unknown movie type. Please use one of: playing, popular, top_rated, upcoming.")
 				os.Exit(1)
 			}
+
+			opts := getListOptions(cmd)
+			client := newClient()
+			movies, err := tmdbapi.Collect(opts.Page, opts.Limit, func(page int) (*tmdbapi.Page[tmdbapi.Movie], error) {
+				return client.Movies(selected.list, page)
+			})
+			exitOnError(err)
+			if opts.Output == "json" {
+				printJSON(movies)
+				return
+			}
+			if len(movies) == 0 {
+				fmt.Printf("No %s movies found.\n", selected.label)
+				return
+			}
+			fmt.Printf("%s Movies:\n", selected.label)
+			printRows(movieRows(movies, &genreLookup{client: client}))
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			reportCacheAge()
 		},
 	}
 
@@ -68,5 +91,12 @@
 		// when this action is called directly.
 		rootCmd.Flags().StringVarP(&movieType, "type", "t", "", "Type of movies to fetch (playing, popular, top_rated, upcoming)")
 		rootCmd.MarkFlagRequired("type")
+		rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (table, json)")
+		rootCmd.PersistentFlags().IntP("page", "p", 1, fmt.Sprintf("Page of results to start at, %d results per page", tmdbapi.PageSize))
+		rootCmd.PersistentFlags().IntP("limit", "l", 20, "Maximum number of results to show")
+		rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Only use cached data, never contact TMDB")
+		rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Always fetch from TMDB, bypassing the local cache")
+		rootCmd.PersistentFlags().StringVar(&language, "language", "", "Language of titles and overviews, e.g. de-DE or fr (default $TMDB_LANGUAGE or en-US)")
+		rootCmd.PersistentFlags().StringVar(&region, "region", "", "Country to narrow movie lists, search and discover to, e.g. GB; TV discover shows that country's shows (default $TMDB_REGION)")
 		//rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"tmdb/tmdbapi"
+
+	"github.com/spf13/cobra"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search movies, TV shows or people",
+	Long: `Search TMDB by title or name.
+Example usage:
+tmdb search fight club
+tmdb search --type tv breaking bad
+tmdb search --type person --limit 5 pitt`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		query := strings.Join(args, " ")
+		mediaType, _ := cmd.Flags().GetString("type")
+		opts := getListOptions(cmd)
+		client := newClient()
+		genres := &genreLookup{client: client}
+
+		switch mediaType {
+		case tmdbapi.MediaMovie:
+			movies, err := tmdbapi.Collect(opts.Page, opts.Limit, func(page int) (*tmdbapi.Page[tmdbapi.Movie], error) {
+				return client.SearchMovies(query, page)
+			})
+			exitOnError(err)
+			if opts.Output == "json" {
+				printJSON(movies)
+				return
+			}
+			printRows(movieRows(movies, genres))
+		case tmdbapi.MediaTV:
+			shows, err := tmdbapi.Collect(opts.Page, opts.Limit, func(page int) (*tmdbapi.Page[tmdbapi.TVShow], error) {
+				return client.SearchTV(query, page)
+			})
+			exitOnError(err)
+			if opts.Output == "json" {
+				printJSON(shows)
+				return
+			}
+			printRows(tvRows(shows, genres))
+		case tmdbapi.MediaPerson:
+			people, err := tmdbapi.Collect(opts.Page, opts.Limit, func(page int) (*tmdbapi.Page[tmdbapi.Person], error) {
+				return client.SearchPeople(query, page)
+			})
+			exitOnError(err)
+			if opts.Output == "json" {
+				printJSON(people)
+				return
+			}
+			printPeople(people)
+		default:
+			fmt.Println("Unknown search type. Please use one of: movie, tv, person.")
+			os.Exit(1)
+		}
+	},
+}
+
+// printPeople prints a table of people with what they are known for
+func printPeople(people []tmdbapi.Person) {
+	if len(people) == 0 {
+		fmt.Println("No results found.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tDEPARTMENT\tKNOWN FOR")
+	for _, p := range people {
+		var knownFor []string
+		for _, credit := range p.KnownFor {
+			knownFor = append(knownFor, credit.DisplayTitle())
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", p.ID, p.Name, orDash(p.KnownForDepartment), orDash(strings.Join(knownFor, ", ")))
+	}
+	w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().StringP("type", "t", tmdbapi.MediaMovie, "What to search for (movie, tv, person)")
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"tmdb/tmdbapi"
+
+	"github.com/spf13/cobra"
+)
+
+// trendingCmd represents the trending command
+var trendingCmd = &cobra.Command{
+	Use:   "trending",
+	Short: "Show what is trending today or this week",
+	Long: `Show the movies, TV shows and people trending on TMDB.
+Example usage:
+tmdb trending
+tmdb trending --window day --type movie`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		window, _ := cmd.Flags().GetString("window")
+		mediaType, _ := cmd.Flags().GetString("type")
+		if window != string(tmdbapi.Day) && window != string(tmdbapi.Week) {
+			fmt.Println("Unknown window. Please use one of: day, week.")
+			os.Exit(1)
+		}
+		switch mediaType {
+		case tmdbapi.MediaAll, tmdbapi.MediaMovie, tmdbapi.MediaTV, tmdbapi.MediaPerson:
+		default:
+			fmt.Println("Unknown trending type. Please use one of: all, movie, tv, person.")
+			os.Exit(1)
+		}
+		opts := getListOptions(cmd)
+		client := newClient()
+
+		items, err := tmdbapi.Collect(opts.Page, opts.Limit, func(page int) (*tmdbapi.Page[tmdbapi.Media], error) {
+			return client.Trending(mediaType, tmdbapi.TimeWindow(window), page)
+		})
+		exitOnError(err)
+		if opts.Output == "json" {
+			printJSON(items)
+			return
+		}
+
+		genres := &genreLookup{client: client}
+		rows := make([]row, len(items))
+		for i, m := range items {
+			rows[i] = row{ID: m.ID, Title: m.DisplayTitle(), Year: m.Year(), Rating: m.VoteAverage, Votes: m.VoteCount, Genres: genres.names(m.MediaType, m.GenreIDs)}
+			if mediaType == tmdbapi.MediaAll {
+				rows[i].Type = m.MediaType
+			}
+		}
+		printRows(rows)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(trendingCmd)
+	trendingCmd.Flags().StringP("window", "w", string(tmdbapi.Week), "Time window (day, week)")
+	trendingCmd.Flags().StringP("type", "t", tmdbapi.MediaAll, "What to show (all, movie, tv, person)")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tmdb/tmdbapi"
+
+	"github.com/spf13/cobra"
+)
+
+// tvCmd represents the tv command
+var tvCmd = &cobra.Command{
+	Use:   "tv <id>",
+	Short: "Show the details of a TV show",
+	Long: `Show the details of a TV show: rating, genres, seasons, networks and cast.
+Example usage:
+tmdb tv 1396`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := parseID(args[0])
+		opts := getListOptions(cmd)
+		show, err := newClient().TV(id)
+		exitOnError(err)
+		if opts.Output == "json" {
+			printJSON(show)
+			return
+		}
+
+		printRows([]row{{ID: show.ID, Title: show.Name, Year: show.Year(), Rating: show.VoteAverage, Votes: show.VoteCount, Genres: genreNames(show.Genres)}})
+		fmt.Println()
+		if show.Tagline != "" {
+			fmt.Println(show.Tagline)
+			fmt.Println()
+		}
+		printField("Seasons", strconv.Itoa(show.NumberOfSeasons))
+		printField("Episodes", strconv.Itoa(show.NumberOfEpisodes))
+		if len(show.EpisodeRunTime) > 0 {
+			printField("Runtime", formatRuntime(show.EpisodeRunTime[0]))
+		}
+		printField("Aired", airDates(show))
+		printField("Created by", companyNames(show.CreatedBy))
+		printField("Networks", companyNames(show.Networks))
+		printField("Status", show.Status)
+		if show.Overview != "" {
+			fmt.Println()
+			fmt.Println(show.Overview)
+		}
+		printCast(show.Credits.Cast)
+	},
+}
+
+// airDates formats when a show aired, e.g. 2008-01-20 to 2013-09-29
+func airDates(show *tmdbapi.TVDetails) string {
+	if show.FirstAirDate == "" {
+		return ""
+	}
+	if show.LastAirDate == "" || show.LastAirDate == show.FirstAirDate {
+		return show.FirstAirDate
+	}
+	return show.FirstAirDate + " to " + show.LastAirDate
+}
+
+func companyNames(companies []tmdbapi.Company) string {
+	names := make([]string, len(companies))
+	for i, c := range companies {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(tvCmd)
+}
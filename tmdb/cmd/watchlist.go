@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"tmdb/library"
+
+	"github.com/spf13/cobra"
+)
+
+// watchlistCmd represents the watchlist command
+var watchlistCmd = &cobra.Command{
+	Use:   "watchlist",
+	Short: "Keep a list of movies to watch",
+	Long: `Keep a local list of movies to watch. Movies are stored by TMDB ID
+with a snapshot of their details, so the list works offline.
+Example usage:
+tmdb watchlist add 550 13
+tmdb watchlist list
+tmdb watchlist remove 13`,
+}
+
+var watchlistAddCmd = &cobra.Command{
+	Use:   "add <id>...",
+	Short: "Add movies to the watchlist",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := parseIDs(args)
+		client := newClient()
+		lib := openLibrary()
+		for _, id := range ids {
+			details, err := client.Movie(id)
+			exitOnError(err)
+			if lib.AddToWatchlist(details) {
+				fmt.Printf("Added %s to the watchlist\n", titleYear(details.Title, details.Year()))
+			} else {
+				fmt.Printf("%s is already on the watchlist\n", titleYear(details.Title, details.Year()))
+			}
+		}
+		exitOnError(lib.Save())
+	},
+}
+
+var watchlistRemoveCmd = &cobra.Command{
+	Use:   "remove <id>...",
+	Short: "Remove movies from the watchlist",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := parseIDs(args)
+		lib := openLibrary()
+		for _, id := range ids {
+			title := fmt.Sprintf("Movie %d", id)
+			if m := lib.Get(id); m != nil {
+				title = titleYear(m.Title, m.Year)
+			}
+			if lib.RemoveFromWatchlist(id) {
+				fmt.Printf("Removed %s from the watchlist\n", title)
+			} else {
+				fmt.Printf("%s is not on the watchlist\n", title)
+			}
+		}
+		exitOnError(lib.Save())
+	},
+}
+
+var watchlistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the movies on the watchlist",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := getListOptions(cmd)
+		watchlist := openLibrary().Watchlist()
+		movies := pageOf(watchlist, opts)
+		if opts.Output == "json" {
+			printJSON(movies)
+			return
+		}
+		if len(watchlist) == 0 {
+			fmt.Println("The watchlist is empty. Add movies with: tmdb watchlist add <id>")
+			return
+		}
+		if len(movies) == 0 {
+			fmt.Printf("No movies on page %d of the watchlist.\n", opts.Page)
+			return
+		}
+		printLibrary(movies, false)
+	},
+}
+
+// openLibrary loads the library from its default location, or exits
+func openLibrary() *library.Library {
+	path, err := library.DefaultPath()
+	exitOnError(err)
+	lib, err := library.Load(path)
+	exitOnError(err)
+	return lib
+}
+
+// printLibrary prints a table of library movies, with their scores if
+// withScore is set
+func printLibrary(movies []library.Movie, withScore bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if withScore {
+		fmt.Fprintln(w, "SCORE\tID\tTITLE\tYEAR\tRATING\tRUNTIME\tGENRES\tRATED")
+	} else {
+		fmt.Fprintln(w, "ID\tTITLE\tYEAR\tRATING\tRUNTIME\tGENRES\tADDED")
+	}
+	for _, m := range movies {
+		if withScore {
+			fmt.Fprintf(w, "%g\t", m.Score)
+		}
+		added := m.AddedAt
+		if withScore {
+			added = m.RatedAt
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			m.ID, m.Title, orDash(m.Year), formatRating(m.VoteAverage, m.VoteCount),
+			orDash(formatRuntime(m.Runtime)), orDash(strings.Join(m.Genres, ", ")), added.Local().Format("2006-01-02"))
+	}
+	w.Flush()
+}
+
+// parseIDs parses TMDB ID arguments, or exits
+func parseIDs(args []string) []int {
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		ids[i] = parseID(arg)
+	}
+	return ids
+}
+
+// titleYear formats a title as e.g. "Fight Club (1999)"
+func titleYear(title, year string) string {
+	if year == "" {
+		return title
+	}
+	return fmt.Sprintf("%s (%s)", title, year)
+}
+
+func init() {
+	rootCmd.AddCommand(watchlistCmd)
+	watchlistCmd.AddCommand(watchlistAddCmd)
+	watchlistCmd.AddCommand(watchlistRemoveCmd)
+	watchlistCmd.AddCommand(watchlistListCmd)
+}
@@ -0,0 +1,232 @@
+package library
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+	"tmdb/tmdbapi"
+)
+
+// MinScore and MaxScore bound a rating; scores go in steps of 0.5, like
+// ratings on TMDB
+const (
+	MinScore = 0.5
+	MaxScore = 10.0
+)
+
+// Movie is a movie in the library: a snapshot of its TMDB metadata plus
+// whether it is on the watchlist and how it was rated
+type Movie struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Year        string    `json:"year,omitempty"`
+	Genres      []string  `json:"genres,omitempty"`
+	Runtime     int       `json:"runtime,omitempty"`
+	VoteAverage float64   `json:"vote_average"`
+	VoteCount   int       `json:"vote_count"`
+	SnapshotAt  time.Time `json:"snapshot_at"`
+
+	Watchlist bool      `json:"watchlist"`
+	AddedAt   time.Time `json:"added_at,omitempty"`
+	Score     float64   `json:"score,omitempty"` // 0 when not rated
+	RatedAt   time.Time `json:"rated_at,omitempty"`
+}
+
+// Seen tells whether the movie has been watched, which rating it implies
+func (m Movie) Seen() bool {
+	return m.Score > 0
+}
+
+// Library is the watchlist and ratings, stored as one JSON file
+type Library struct {
+	Path   string  `json:"-"`
+	Movies []Movie `json:"movies"`
+}
+
+// DefaultPath is library.json in the user's config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding config directory: %w", err)
+	}
+	return filepath.Join(dir, "tmdb", "library.json"), nil
+}
+
+// Load reads the library at path; a missing file is an empty library
+func Load(path string) (*Library, error) {
+	lib := &Library{Path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lib, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading library: %w", err)
+	}
+	if err := json.Unmarshal(data, lib); err != nil {
+		return nil, fmt.Errorf("error parsing library %s: %w", path, err)
+	}
+	return lib, nil
+}
+
+// Save writes the library back to its file
+func (l *Library) Save() error {
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0755); err != nil {
+		return fmt.Errorf("error creating library directory: %w", err)
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling library: %w", err)
+	}
+	// Write a temp file and rename it over the library, so a crash part
+	// way never leaves a truncated library behind
+	tmp, err := os.CreateTemp(filepath.Dir(l.Path), filepath.Base(l.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error writing library: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing library: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing library: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing library: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("error writing library: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), l.Path); err != nil {
+		return fmt.Errorf("error writing library: %w", err)
+	}
+	return nil
+}
+
+// Get returns the movie with a TMDB ID, or nil
+func (l *Library) Get(id int) *Movie {
+	for i := range l.Movies {
+		if l.Movies[i].ID == id {
+			return &l.Movies[i]
+		}
+	}
+	return nil
+}
+
+// put returns the movie for details, adding it if needed, with a fresh
+// metadata snapshot
+func (l *Library) put(details *tmdbapi.MovieDetails) *Movie {
+	m := l.Get(details.ID)
+	if m == nil {
+		l.Movies = append(l.Movies, Movie{ID: details.ID})
+		m = &l.Movies[len(l.Movies)-1]
+	}
+	m.Title = details.Title
+	m.Year = details.Year()
+	m.Runtime = details.Runtime
+	m.VoteAverage = details.VoteAverage
+	m.VoteCount = details.VoteCount
+	m.Genres = nil
+	for _, g := range details.Genres {
+		m.Genres = append(m.Genres, g.Name)
+	}
+	m.SnapshotAt = time.Now()
+	return m
+}
+
+// AddToWatchlist puts a movie on the watchlist. It reports false if the
+// movie was already there.
+func (l *Library) AddToWatchlist(details *tmdbapi.MovieDetails) bool {
+	m := l.put(details)
+	if m.Watchlist {
+		return false
+	}
+	m.Watchlist = true
+	m.AddedAt = time.Now()
+	return true
+}
+
+// RemoveFromWatchlist takes a movie off the watchlist, forgetting it unless
+// it is rated. It reports false if the movie was not on the watchlist.
+func (l *Library) RemoveFromWatchlist(id int) bool {
+	m := l.Get(id)
+	if m == nil || !m.Watchlist {
+		return false
+	}
+	m.Watchlist = false
+	m.AddedAt = time.Time{}
+	if !m.Seen() {
+		l.remove(id)
+	}
+	return true
+}
+
+func (l *Library) remove(id int) {
+	for i := range l.Movies {
+		if l.Movies[i].ID == id {
+			l.Movies = append(l.Movies[:i], l.Movies[i+1:]...)
+			return
+		}
+	}
+}
+
+// Rate records a score for a movie, which marks it as seen and takes it
+// off the watchlist. It returns the movie as stored.
+func (l *Library) Rate(details *tmdbapi.MovieDetails, score float64) (*Movie, error) {
+	if err := CheckScore(score); err != nil {
+		return nil, err
+	}
+	m := l.put(details)
+	m.Score = score
+	m.RatedAt = time.Now()
+	m.Watchlist = false
+	m.AddedAt = time.Time{}
+	return m, nil
+}
+
+// CheckScore checks that a score is between MinScore and MaxScore in steps
+// of 0.5
+func CheckScore(score float64) error {
+	if score < MinScore || score > MaxScore || math.Mod(score*2, 1) != 0 {
+		return fmt.Errorf("score must be between %g and %g in steps of 0.5", MinScore, MaxScore)
+	}
+	return nil
+}
+
+// Watchlist returns the movies on the watchlist, oldest addition first
+func (l *Library) Watchlist() []Movie {
+	var movies []Movie
+	for _, m := range l.Movies {
+		if m.Watchlist {
+			movies = append(movies, m)
+		}
+	}
+	sort.SliceStable(movies, func(i, j int) bool {
+		return movies[i].AddedAt.Before(movies[j].AddedAt)
+	})
+	return movies
+}
+
+// Rated returns the rated movies, highest score first and most recently
+// rated first among equal scores
+func (l *Library) Rated() []Movie {
+	var movies []Movie
+	for _, m := range l.Movies {
+		if m.Seen() {
+			movies = append(movies, m)
+		}
+	}
+	sort.SliceStable(movies, func(i, j int) bool {
+		if movies[i].Score != movies[j].Score {
+			return movies[i].Score > movies[j].Score
+		}
+		return movies[i].RatedAt.After(movies[j].RatedAt)
+	})
+	return movies
+}
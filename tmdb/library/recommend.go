@@ -0,0 +1,76 @@
+package library
+
+import (
+	"sort"
+	"tmdb/tmdbapi"
+)
+
+// Recommendation is a movie recommended from one or more rated movies
+type Recommendation struct {
+	tmdbapi.Movie
+	// Because are the titles of the rated movies that led to this one
+	Because []string `json:"because"`
+	// Score ranks recommendations; it grows with the number of seeds
+	// recommending the movie and with how highly they were rated
+	Score float64 `json:"score"`
+}
+
+// Seeds returns up to n rated movies scored at least minScore, the best
+// and most recent first
+func (l *Library) Seeds(minScore float64, n int) []Movie {
+	var seeds []Movie
+	for _, m := range l.Rated() {
+		if m.Score >= minScore && len(seeds) < n {
+			seeds = append(seeds, m)
+		}
+	}
+	return seeds
+}
+
+// Recommend asks TMDB for recommendations based on each seed and merges
+// them, leaving out movies already seen. Seeds whose recommendations
+// cannot be fetched are skipped; the last error is returned only if none
+// could be fetched.
+func (l *Library) Recommend(client *tmdbapi.Client, seeds []Movie) ([]Recommendation, error) {
+	byID := make(map[int]*Recommendation)
+	var order []int
+	var lastErr error
+	fetched := 0
+	for _, seed := range seeds {
+		page, err := client.Recommendations(seed.ID, 1)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		fetched++
+		for rank, movie := range page.Results {
+			if m := l.Get(movie.ID); m != nil && m.Seen() {
+				continue
+			}
+			rec, ok := byID[movie.ID]
+			if !ok {
+				rec = &Recommendation{Movie: movie}
+				byID[movie.ID] = rec
+				order = append(order, movie.ID)
+			}
+			rec.Because = append(rec.Because, seed.Title)
+			// TMDB's own order counts too: earlier results weigh more
+			rec.Score += seed.Score * (1 - float64(rank)/float64(2*len(page.Results)))
+		}
+	}
+	if fetched == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	recs := make([]Recommendation, 0, len(order))
+	for _, id := range order {
+		recs = append(recs, *byID[id])
+	}
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].VoteAverage > recs[j].VoteAverage
+	})
+	return recs, nil
+}
@@ -2,84 +2,181 @@ package tmdbapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 )
 
-type Movie struct {
-	Title string `json:"title"`
+const (
+	// DefaultBaseURL is the TMDB API, version 3
+	DefaultBaseURL = "https://api.themoviedb.org/3"
+	// DefaultLanguage is the language results are returned in
+	DefaultLanguage = "en-US"
+	// APIKeyEnv is the environment variable the API key is read from
+	APIKeyEnv = "TMDB_API_KEY"
+	// BaseURLEnv overrides DefaultBaseURL, e.g. to use a local fake
+	BaseURLEnv = "TMDB_API_URL"
+	// LanguageEnv overrides DefaultLanguage
+	LanguageEnv = "TMDB_LANGUAGE"
+	// RegionEnv sets the default region
+	RegionEnv = "TMDB_REGION"
+)
+
+// ErrNoAPIKey is returned by NewClientFromEnv when no API key is set
+var ErrNoAPIKey = errors.New("please set the " + APIKeyEnv + " environment variable")
+
+// Client talks to the TMDB API. The zero value is not usable; create one
+// with NewClient or NewClientFromEnv.
+type Client struct {
+	BaseURL string
+	// APIKey is either a v4 read access token (sent as a Bearer token) or
+	// a v3 API key (sent as the api_key parameter)
+	APIKey string
+	// Language is the language of titles and overviews, an ISO 639-1 code
+	// with an optional country, e.g. en-US or fr
+	Language string
+	// Region is an ISO 3166-1 country code such as US or DE. It narrows
+	// movie lists, movie search and discover to releases in that country.
+	Region     string
+	HTTPClient *http.Client
+	// Cache, if set, stores responses on disk
+	Cache *Cache
+	// Offline serves responses from the cache only
+	Offline bool
+
+	cacheUsage CacheUsage
 }
 
-type ApiResponse struct {
-	Results []Movie `json:"results"`
+// APIError is an error response from TMDB
+type APIError struct {
+	StatusCode    int    // HTTP status
+	Code          int    `json:"status_code"` // TMDB status code
+	StatusMessage string `json:"status_message"`
 }
 
-func fetchAndPrintMovies(url string, label string) {
-	apiKey := os.Getenv("TMDB_API_KEY")
-	if apiKey == "" {
-		fmt.Println("Please set the TMDB_API_KEY environment variable.")
-		os.Exit(1)
+func (e *APIError) Error() string {
+	if e.StatusMessage != "" {
+		return fmt.Sprintf("TMDB API error (%d): %s", e.StatusCode, e.StatusMessage)
 	}
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		fmt.Println("Error creating request:", err)
-		return
-	}
-	req.Header.Set("Authorization", "Bearer "+apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{}
-	// Make the request
-	resp, err := client.Do(req)
-	if err != nil {
-		fmt.Println("Error making request:", err)
-		return
-	}
-	defer resp.Body.Close()
+	return fmt.Sprintf("TMDB API error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Println("Error while reading response body:", err)
-		return
+// NewClient returns a client for the TMDB API using the given key
+func NewClient(apiKey string) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		APIKey:     apiKey,
+		Language:   DefaultLanguage,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
 	}
-	// Parse the JSON response
-	var apiResp ApiResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		fmt.Println("Error parsing JSON:", err)
-		return
+}
+
+// NewClientFromEnv returns a client using the key in TMDB_API_KEY and,
+// if set, the base URL, language and region in TMDB_API_URL,
+// TMDB_LANGUAGE and TMDB_REGION
+func NewClientFromEnv() (*Client, error) {
+	apiKey := os.Getenv(APIKeyEnv)
+	if apiKey == "" {
+		return nil, ErrNoAPIKey
 	}
-	if len(apiResp.Results) == 0 {
-		fmt.Printf("No %s movies found.\n", label)
-		return
+	client := NewClient(apiKey)
+	if baseURL := os.Getenv(BaseURLEnv); baseURL != "" {
+		client.BaseURL = baseURL
 	}
-	fmt.Printf("%s Movies:\n", label)
-	for _, movie := range apiResp.Results {
-		fmt.Println("- " + movie.Title)
+	if language := os.Getenv(LanguageEnv); language != "" {
+		client.Language = language
 	}
+	client.Region = os.Getenv(RegionEnv)
+	return client, nil
 }
 
-func Playing() {
-	// Implement the logic to fetch currently playing movies
-	fmt.Println("Fetching currently playing movies...")
-	url := "https://api.themoviedb.org/3/movie/now_playing?language=en-US&page=1"
-	fetchAndPrintMovies(url, "Currently Playing")
+// regionParams adds the client's region to the parameters of an endpoint
+// that supports it
+func (c *Client) regionParams(params url.Values) url.Values {
+	if c.Region != "" && !params.Has("region") {
+		params.Set("region", c.Region)
+	}
+	return params
 }
 
-func Popular() {
-	fmt.Println("Fetching popular movies...")
-	url := "https://api.themoviedb.org/3/movie/popular?language=en-US&page=1"
-	fetchAndPrintMovies(url, "Popular")
+// isBearerToken tells a v4 read access token (a JWT) from a v3 API key
+func isBearerToken(key string) bool {
+	return strings.Count(key, ".") == 2
 }
 
-func TopRated() {
-	fmt.Println("Fetching top-rated movies...")
-	url := "https://api.themoviedb.org/3/movie/top_rated?language=en-US&page=1"
-	fetchAndPrintMovies(url, "Top Rated")
+// get requests path with the given query parameters and decodes the JSON
+// response into out
+func (c *Client) get(apiPath string, params url.Values, out any) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	if c.Language != "" && !params.Has("language") {
+		params.Set("language", c.Language)
+	}
+	// The cache key leaves out the API key
+	request := apiPath
+	if encoded := params.Encode(); encoded != "" {
+		request += "?" + encoded
+	}
+
+	body, err := c.cached(request, apiPath, func() ([]byte, error) {
+		return c.fetch(apiPath, params)
+	})
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error parsing JSON: %w", err)
+	}
+	return nil
 }
 
-func Upcoming() {
-	fmt.Println("Fetching upcoming movies...")
-	url := "https://api.themoviedb.org/3/movie/upcoming?language=en-US&page=1"
-	fetchAndPrintMovies(url, "Upcoming")
+// fetch requests an API path from TMDB and returns the response body
+func (c *Client) fetch(apiPath string, params url.Values) ([]byte, error) {
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	if c.APIKey != "" && !isBearerToken(c.APIKey) {
+		query.Set("api_key", c.APIKey)
+	}
+	endpoint := strings.TrimRight(c.BaseURL, "/") + apiPath
+	if encoded := query.Encode(); encoded != "" {
+		endpoint += "?" + encoded
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if isBearerToken(c.APIKey) {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		json.Unmarshal(body, apiErr) // best effort, the status is enough
+		return nil, apiErr
+	}
+	return body, nil
 }
@@ -0,0 +1,174 @@
+package tmdbapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// EndpointTTL is how long responses of the endpoints matching Pattern (a
+// path.Match pattern such as "/movie/*") stay fresh
+type EndpointTTL struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// DefaultTTLs are the cache lifetimes per endpoint, checked in order.
+// Details rarely change; lists and trending change daily or faster.
+var DefaultTTLs = []EndpointTTL{
+	{"/genre/*/list", 30 * 24 * time.Hour},
+	{"/trending/*/day", time.Hour},
+	{"/trending/*/week", 6 * time.Hour},
+	{"/movie/now_playing", 6 * time.Hour},
+	{"/movie/upcoming", 6 * time.Hour},
+	{"/movie/popular", 12 * time.Hour},
+	{"/movie/top_rated", 24 * time.Hour},
+	{"/search/*", 24 * time.Hour},
+	{"/discover/*", 12 * time.Hour},
+	{"/movie/*/recommendations", 24 * time.Hour},
+	{"/movie/*", 7 * 24 * time.Hour},
+	{"/tv/*", 7 * 24 * time.Hour},
+	{"/person/*", 7 * 24 * time.Hour},
+}
+
+// DefaultCacheTTL is the lifetime of endpoints not in DefaultTTLs
+const DefaultCacheTTL = time.Hour
+
+// Cache stores API responses on disk
+type Cache struct {
+	Dir        string
+	TTLs       []EndpointTTL
+	DefaultTTL time.Duration
+}
+
+// cacheEntry is one cached response
+type cacheEntry struct {
+	Request   string          `json:"request"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// CacheUsage tells how cached responses were used by a client
+type CacheUsage struct {
+	// Oldest is when the oldest response served from the cache was
+	// fetched; zero if nothing came from the cache
+	Oldest time.Time
+	// Stale is set when a response past its TTL was served, because the
+	// client was offline or TMDB could not be reached
+	Stale bool
+}
+
+// OfflineError is returned in offline mode for requests that are not in
+// the cache
+type OfflineError struct {
+	Request string
+}
+
+func (e *OfflineError) Error() string {
+	return fmt.Sprintf("%s is not in the cache; run it once without --offline", e.Request)
+}
+
+// NewCache returns a cache in the user's cache directory with the default
+// TTLs
+func NewCache() (*Cache, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("error finding cache directory: %w", err)
+	}
+	return &Cache{Dir: filepath.Join(dir, "tmdb"), TTLs: DefaultTTLs, DefaultTTL: DefaultCacheTTL}, nil
+}
+
+// TTL is how long responses of an API path stay fresh
+func (c *Cache) TTL(apiPath string) time.Duration {
+	for _, t := range c.TTLs {
+		if ok, _ := path.Match(t.Pattern, apiPath); ok {
+			return t.TTL
+		}
+	}
+	return c.DefaultTTL
+}
+
+// file is where a request is cached; requests are hashed so any query
+// string makes a valid file name
+func (c *Cache) file(request string) string {
+	sum := sha256.Sum256([]byte(request))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func (c *Cache) load(request string) *cacheEntry {
+	data, err := os.ReadFile(c.file(request))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil || entry.Request != request {
+		return nil
+	}
+	return &entry
+}
+
+func (c *Cache) save(entry *cacheEntry) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshalling cache entry: %w", err)
+	}
+	return os.WriteFile(c.file(entry.Request), data, 0644)
+}
+
+// cached returns the response to a request from the cache if it is fresh
+// (or the client is offline), and otherwise from fetch, caching it. When
+// TMDB cannot be reached, a stale cached response is better than none.
+func (c *Client) cached(request, apiPath string, fetch func() ([]byte, error)) ([]byte, error) {
+	if c.Cache == nil {
+		if c.Offline {
+			return nil, &OfflineError{Request: request}
+		}
+		return fetch()
+	}
+
+	entry := c.Cache.load(request)
+	if entry != nil {
+		fresh := time.Since(entry.FetchedAt) < c.Cache.TTL(apiPath)
+		if fresh || c.Offline {
+			c.noteCached(entry, !fresh)
+			return entry.Body, nil
+		}
+	}
+	if c.Offline {
+		return nil, &OfflineError{Request: request}
+	}
+
+	body, err := fetch()
+	if err != nil {
+		var apiErr *APIError
+		if entry != nil && !errors.As(err, &apiErr) {
+			c.noteCached(entry, true)
+			return entry.Body, nil
+		}
+		return nil, err
+	}
+	// A cache that cannot be written only costs a request next time
+	c.Cache.save(&cacheEntry{Request: request, FetchedAt: time.Now(), Body: body})
+	return body, nil
+}
+
+func (c *Client) noteCached(entry *cacheEntry, stale bool) {
+	if c.cacheUsage.Oldest.IsZero() || entry.FetchedAt.Before(c.cacheUsage.Oldest) {
+		c.cacheUsage.Oldest = entry.FetchedAt
+	}
+	c.cacheUsage.Stale = c.cacheUsage.Stale || stale
+}
+
+// CacheUsage reports how the cache was used by the requests so far
+func (c *Client) CacheUsage() CacheUsage {
+	return c.cacheUsage
+}
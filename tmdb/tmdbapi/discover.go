@@ -0,0 +1,131 @@
+package tmdbapi
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DiscoverOptions are the filters of the discover endpoints. Zero values
+// leave a filter out.
+type DiscoverOptions struct {
+	Genres           []int // all of these genres
+	YearFrom         int
+	YearTo           int
+	MinRating        float64
+	MinVotes         int
+	MinRuntime       int    // minutes
+	MaxRuntime       int    // minutes
+	OriginalLanguage string // ISO 639-1, e.g. ko
+	SortBy           string // a TMDB sort order such as vote_average.desc, see SortOrder
+}
+
+// sortOrders maps friendly sort names to TMDB sort orders, per media type
+var sortOrders = map[string]map[string]string{
+	MediaMovie: {
+		"popularity": "popularity.desc",
+		"rating":     "vote_average.desc",
+		"votes":      "vote_count.desc",
+		"release":    "primary_release_date.desc",
+		"revenue":    "revenue.desc",
+		"title":      "title.asc",
+	},
+	MediaTV: {
+		"popularity": "popularity.desc",
+		"rating":     "vote_average.desc",
+		"votes":      "vote_count.desc",
+		"release":    "first_air_date.desc",
+		"title":      "name.asc",
+	},
+}
+
+// SortOrder turns a friendly sort name (popularity, rating, votes,
+// release, revenue, title) into a TMDB sort order for a media type. TMDB
+// sort orders such as vote_count.asc are passed through.
+func SortOrder(mediaType, name string) (string, error) {
+	if strings.HasSuffix(name, ".asc") || strings.HasSuffix(name, ".desc") {
+		return name, nil
+	}
+	if order, ok := sortOrders[mediaType][name]; ok {
+		return order, nil
+	}
+	var names []string
+	for n := range sortOrders[mediaType] {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("unknown sort order %q (use one of %s, or a TMDB sort order like vote_count.desc)", name, strings.Join(names, ", "))
+}
+
+// params converts the options to query parameters for a media type
+func (o DiscoverOptions) params(mediaType string) url.Values {
+	params := url.Values{}
+	dateField := "primary_release_date"
+	if mediaType == MediaTV {
+		dateField = "first_air_date"
+	}
+	if len(o.Genres) > 0 {
+		ids := make([]string, len(o.Genres))
+		for i, id := range o.Genres {
+			ids[i] = strconv.Itoa(id)
+		}
+		params.Set("with_genres", strings.Join(ids, ","))
+	}
+	if o.YearFrom > 0 {
+		params.Set(dateField+".gte", fmt.Sprintf("%04d-01-01", o.YearFrom))
+	}
+	if o.YearTo > 0 {
+		params.Set(dateField+".lte", fmt.Sprintf("%04d-12-31", o.YearTo))
+	}
+	if o.MinRating > 0 {
+		params.Set("vote_average.gte", strconv.FormatFloat(o.MinRating, 'f', -1, 64))
+	}
+	if o.MinVotes > 0 {
+		params.Set("vote_count.gte", strconv.Itoa(o.MinVotes))
+	}
+	if o.MinRuntime > 0 {
+		params.Set("with_runtime.gte", strconv.Itoa(o.MinRuntime))
+	}
+	if o.MaxRuntime > 0 {
+		params.Set("with_runtime.lte", strconv.Itoa(o.MaxRuntime))
+	}
+	if o.OriginalLanguage != "" {
+		params.Set("with_original_language", o.OriginalLanguage)
+	}
+	if o.SortBy != "" {
+		params.Set("sort_by", o.SortBy)
+	}
+	return params
+}
+
+// DiscoverMovies fetches a page of movies matching the options
+func (c *Client) DiscoverMovies(opts DiscoverOptions, page int) (*Page[Movie], error) {
+	params := c.regionParams(opts.params(MediaMovie))
+	if page > 1 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	var result Page[Movie]
+	if err := c.get("/discover/movie", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiscoverTV fetches a page of TV shows matching the options. TV has no
+// release region, so the client's region picks shows from that country.
+func (c *Client) DiscoverTV(opts DiscoverOptions, page int) (*Page[TVShow], error) {
+	params := opts.params(MediaTV)
+	if c.Region != "" {
+		params.Set("with_origin_country", c.Region)
+	}
+	if page > 1 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	var result Page[TVShow]
+	if err := c.get("/discover/tv", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
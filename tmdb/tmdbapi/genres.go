@@ -0,0 +1,65 @@
+package tmdbapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MovieGenres fetches the list of movie genres
+func (c *Client) MovieGenres() ([]Genre, error) {
+	return c.genres("/genre/movie/list")
+}
+
+// TVGenres fetches the list of TV genres
+func (c *Client) TVGenres() ([]Genre, error) {
+	return c.genres("/genre/tv/list")
+}
+
+func (c *Client) genres(path string) ([]Genre, error) {
+	var result struct {
+		Genres []Genre `json:"genres"`
+	}
+	if err := c.get(path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Genres, nil
+}
+
+// GenreNames looks up the names of genre IDs, skipping unknown IDs
+func GenreNames(ids []int, genres []Genre) []string {
+	byID := make(map[int]string, len(genres))
+	for _, g := range genres {
+		byID[g.ID] = g.Name
+	}
+	var names []string
+	for _, id := range ids {
+		if name, ok := byID[id]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GenreIDs looks up genres by name (case-insensitive) or ID
+func GenreIDs(names []string, genres []Genre) ([]int, error) {
+	var ids []int
+	for _, name := range names {
+		found := false
+		for _, g := range genres {
+			if strings.EqualFold(g.Name, name) || strconv.Itoa(g.ID) == name {
+				ids = append(ids, g.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			known := make([]string, len(genres))
+			for i, g := range genres {
+				known[i] = g.Name
+			}
+			return nil, fmt.Errorf("unknown genre %q (known genres: %s)", name, strings.Join(known, ", "))
+		}
+	}
+	return ids, nil
+}
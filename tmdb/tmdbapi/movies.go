@@ -0,0 +1,139 @@
+package tmdbapi
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Movie is a movie as it appears in listings and search results
+type Movie struct {
+	ID               int     `json:"id"`
+	Title            string  `json:"title"`
+	OriginalTitle    string  `json:"original_title"`
+	OriginalLanguage string  `json:"original_language"`
+	Overview         string  `json:"overview"`
+	ReleaseDate      string  `json:"release_date"` // YYYY-MM-DD, may be empty
+	GenreIDs         []int   `json:"genre_ids,omitempty"`
+	Popularity       float64 `json:"popularity"`
+	VoteAverage      float64 `json:"vote_average"`
+	VoteCount        int     `json:"vote_count"`
+	PosterPath       string  `json:"poster_path"`
+	Adult            bool    `json:"adult"`
+}
+
+// Year is the release year, or "" if unknown
+func (m Movie) Year() string {
+	return year(m.ReleaseDate)
+}
+
+// Genre is a movie or TV genre
+type Genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// MovieDetails is everything about one movie, including its credits
+type MovieDetails struct {
+	Movie
+	Genres   []Genre `json:"genres"`
+	Runtime  int     `json:"runtime"` // minutes
+	Tagline  string  `json:"tagline"`
+	Status   string  `json:"status"`
+	Budget   int64   `json:"budget"`
+	Revenue  int64   `json:"revenue"`
+	Homepage string  `json:"homepage"`
+	IMDbID   string  `json:"imdb_id"`
+	Credits  Credits `json:"credits"`
+}
+
+// Credits are the cast and crew of a movie or TV show
+type Credits struct {
+	Cast []CastMember `json:"cast"`
+	Crew []CrewMember `json:"crew"`
+}
+
+// CastMember is an actor and the character they play
+type CastMember struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Character string `json:"character"`
+	Order     int    `json:"order"`
+}
+
+// CrewMember is someone who worked on a movie or TV show behind the camera
+type CrewMember struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Job        string `json:"job"`
+	Department string `json:"department"`
+}
+
+// Directors returns the crew members credited as director
+func (c Credits) Directors() []CrewMember {
+	var directors []CrewMember
+	for _, member := range c.Crew {
+		if member.Job == "Director" {
+			directors = append(directors, member)
+		}
+	}
+	return directors
+}
+
+// MovieList names one of TMDB's curated movie lists
+type MovieList string
+
+// The curated movie lists
+const (
+	NowPlaying MovieList = "now_playing"
+	Popular    MovieList = "popular"
+	TopRated   MovieList = "top_rated"
+	Upcoming   MovieList = "upcoming"
+)
+
+// Movies fetches a page of one of the curated movie lists
+func (c *Client) Movies(list MovieList, page int) (*Page[Movie], error) {
+	var result Page[Movie]
+	if err := c.get("/movie/"+string(list), c.regionParams(pageParams(page)), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchMovies searches movies by title
+func (c *Client) SearchMovies(query string, page int) (*Page[Movie], error) {
+	params := c.regionParams(pageParams(page))
+	params.Set("query", query)
+	var result Page[Movie]
+	if err := c.get("/search/movie", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Movie fetches the details and credits of a movie
+func (c *Client) Movie(id int) (*MovieDetails, error) {
+	params := url.Values{"append_to_response": {"credits"}}
+	var result MovieDetails
+	if err := c.get(fmt.Sprintf("/movie/%d", id), params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Recommendations fetches a page of movies TMDB recommends to people who
+// liked a movie
+func (c *Client) Recommendations(id, page int) (*Page[Movie], error) {
+	var result Page[Movie]
+	if err := c.get(fmt.Sprintf("/movie/%d/recommendations", id), pageParams(page), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// year returns the year of a YYYY-MM-DD date
+func year(date string) string {
+	if len(date) < 4 {
+		return ""
+	}
+	return date[:4]
+}
@@ -0,0 +1,56 @@
+package tmdbapi
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// MaxPage is the highest page TMDB returns for any listing
+const MaxPage = 500
+
+// PageSize is the number of results on each page of a TMDB listing
+const PageSize = 20
+
+// Page is one page of a paged listing
+type Page[T any] struct {
+	Page         int `json:"page"`
+	Results      []T `json:"results"`
+	TotalPages   int `json:"total_pages"`
+	TotalResults int `json:"total_results"`
+}
+
+// HasNext tells whether there is a page after this one
+func (p *Page[T]) HasNext() bool {
+	return p.Page < p.TotalPages && p.Page < MaxPage
+}
+
+// Collect fetches pages starting at page first until it has limit results
+// or runs out of pages
+func Collect[T any](first, limit int, fetch func(page int) (*Page[T], error)) ([]T, error) {
+	if first < 1 {
+		first = 1
+	}
+	var results []T
+	for page := first; ; page++ {
+		p, err := fetch(page)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, p.Results...)
+		if len(results) >= limit {
+			return results[:limit], nil
+		}
+		if !p.HasNext() {
+			return results, nil
+		}
+	}
+}
+
+// pageParams returns the query parameters for a page of a listing
+func pageParams(page int) url.Values {
+	params := url.Values{}
+	if page > 1 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	return params
+}
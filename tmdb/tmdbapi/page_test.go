@@ -0,0 +1,113 @@
+package tmdbapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"tmdb/tmdbapi"
+)
+
+// fakePages serves total pages of perPage results each, numbering the
+// results from 1, and records which pages were asked for
+func fakePages(total, perPage int, requested *[]int) func(page int) (*tmdbapi.Page[int], error) {
+	return func(page int) (*tmdbapi.Page[int], error) {
+		*requested = append(*requested, page)
+		p := &tmdbapi.Page[int]{Page: page, TotalPages: total, TotalResults: total * perPage}
+		for i := 0; i < perPage; i++ {
+			p.Results = append(p.Results, (page-1)*perPage+i+1)
+		}
+		return p, nil
+	}
+}
+
+func TestCollectStopsAtLimit(t *testing.T) {
+	var requested []int
+	results, err := tmdbapi.Collect(1, 25, fakePages(10, 20, &requested))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 25 {
+		t.Fatalf("expected 25 results, got %d", len(results))
+	}
+	if len(requested) != 2 {
+		t.Fatalf("expected 2 pages fetched, got %v", requested)
+	}
+	if results[24] != 25 {
+		t.Fatalf("expected last result 25, got %d", results[24])
+	}
+}
+
+func TestCollectStopsAtLastPage(t *testing.T) {
+	var requested []int
+	results, err := tmdbapi.Collect(2, 100, fakePages(3, 20, &requested))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 40 {
+		t.Fatalf("expected 40 results, got %d", len(results))
+	}
+	if len(requested) != 2 || requested[0] != 2 || requested[1] != 3 {
+		t.Fatalf("expected pages 2 and 3, got %v", requested)
+	}
+}
+
+func TestCollectStartsAtPageOne(t *testing.T) {
+	var requested []int
+	if _, err := tmdbapi.Collect(0, 5, fakePages(1, 20, &requested)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(requested) != 1 || requested[0] != 1 {
+		t.Fatalf("expected page 1, got %v", requested)
+	}
+}
+
+func TestCollectReturnsPartialResultsOnError(t *testing.T) {
+	fetchErr := errors.New("boom")
+	var requested []int
+	pages := fakePages(5, 20, &requested)
+	results, err := tmdbapi.Collect(1, 100, func(page int) (*tmdbapi.Page[int], error) {
+		if page == 2 {
+			return nil, fetchErr
+		}
+		return pages(page)
+	})
+	if !errors.Is(err, fetchErr) {
+		t.Fatalf("expected fetch error, got %v", err)
+	}
+	if len(results) != 20 {
+		t.Fatalf("expected the 20 results of page 1, got %d", len(results))
+	}
+}
+
+func TestHasNext(t *testing.T) {
+	tests := []struct {
+		page, total int
+		want        bool
+	}{
+		{1, 3, true},
+		{3, 3, false},
+		{1, 0, false},
+		{tmdbapi.MaxPage - 1, 1000, true},
+		{tmdbapi.MaxPage, 1000, false},
+	}
+	for _, tt := range tests {
+		p := tmdbapi.Page[int]{Page: tt.page, TotalPages: tt.total}
+		if got := p.HasNext(); got != tt.want {
+			t.Errorf("page %d of %d: expected HasNext %v, got %v", tt.page, tt.total, tt.want, got)
+		}
+	}
+}
+
+func TestCollectStopsAtMaxPage(t *testing.T) {
+	var requested []int
+	results, err := tmdbapi.Collect(tmdbapi.MaxPage-1, 1000, fakePages(1000, 20, &requested))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(requested) != 2 || requested[1] != tmdbapi.MaxPage {
+		t.Fatalf("expected to stop at page %d, got %v", tmdbapi.MaxPage, requested)
+	}
+	if len(results) != 40 {
+		t.Fatalf("expected 40 results, got %d", len(results))
+	}
+}
@@ -0,0 +1,88 @@
+package tmdbapi
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Person is a person as they appear in search results
+type Person struct {
+	ID                 int      `json:"id"`
+	Name               string   `json:"name"`
+	KnownForDepartment string   `json:"known_for_department"`
+	Popularity         float64  `json:"popularity"`
+	ProfilePath        string   `json:"profile_path"`
+	KnownFor           []Credit `json:"known_for,omitempty"`
+}
+
+// PersonDetails is everything about one person, including the movies and
+// shows they worked on
+type PersonDetails struct {
+	Person
+	Biography    string        `json:"biography"`
+	Birthday     string        `json:"birthday"`
+	Deathday     string        `json:"deathday"`
+	PlaceOfBirth string        `json:"place_of_birth"`
+	AlsoKnownAs  []string      `json:"also_known_as"`
+	IMDbID       string        `json:"imdb_id"`
+	Credits      PersonCredits `json:"combined_credits"`
+}
+
+// PersonCredits are the movies and shows a person acted in or worked on
+type PersonCredits struct {
+	Cast []Credit `json:"cast"`
+	Crew []Credit `json:"crew"`
+}
+
+// Credit is a movie or TV show a person is credited on. Movies have a
+// Title and ReleaseDate, TV shows a Name and FirstAirDate.
+type Credit struct {
+	ID           int     `json:"id"`
+	MediaType    string  `json:"media_type"` // movie or tv
+	Title        string  `json:"title,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	ReleaseDate  string  `json:"release_date,omitempty"`
+	FirstAirDate string  `json:"first_air_date,omitempty"`
+	Character    string  `json:"character,omitempty"`
+	Job          string  `json:"job,omitempty"`
+	VoteAverage  float64 `json:"vote_average"`
+	VoteCount    int     `json:"vote_count"`
+	GenreIDs     []int   `json:"genre_ids,omitempty"`
+}
+
+// DisplayTitle is the title of a movie or the name of a show
+func (c Credit) DisplayTitle() string {
+	if c.Title != "" {
+		return c.Title
+	}
+	return c.Name
+}
+
+// Year is the release or first air year, or "" if unknown
+func (c Credit) Year() string {
+	if c.ReleaseDate != "" {
+		return year(c.ReleaseDate)
+	}
+	return year(c.FirstAirDate)
+}
+
+// SearchPeople searches people by name
+func (c *Client) SearchPeople(query string, page int) (*Page[Person], error) {
+	params := pageParams(page)
+	params.Set("query", query)
+	var result Page[Person]
+	if err := c.get("/search/person", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Person fetches the details and combined movie and TV credits of a person
+func (c *Client) Person(id int) (*PersonDetails, error) {
+	params := url.Values{"append_to_response": {"combined_credits"}}
+	var result PersonDetails
+	if err := c.get(fmt.Sprintf("/person/%d", id), params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
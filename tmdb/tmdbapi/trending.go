@@ -0,0 +1,62 @@
+package tmdbapi
+
+import "fmt"
+
+// TimeWindow is the period trending results are computed over
+type TimeWindow string
+
+// The trending time windows
+const (
+	Day  TimeWindow = "day"
+	Week TimeWindow = "week"
+)
+
+// Media types for Trending
+const (
+	MediaAll    = "all"
+	MediaMovie  = "movie"
+	MediaTV     = "tv"
+	MediaPerson = "person"
+)
+
+// Media is a trending movie, TV show or person. Movies have a Title and
+// ReleaseDate, TV shows and people a Name.
+type Media struct {
+	ID           int     `json:"id"`
+	MediaType    string  `json:"media_type"`
+	Title        string  `json:"title,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	ReleaseDate  string  `json:"release_date,omitempty"`
+	FirstAirDate string  `json:"first_air_date,omitempty"`
+	Overview     string  `json:"overview,omitempty"`
+	GenreIDs     []int   `json:"genre_ids,omitempty"`
+	Popularity   float64 `json:"popularity"`
+	VoteAverage  float64 `json:"vote_average"`
+	VoteCount    int     `json:"vote_count"`
+}
+
+// DisplayTitle is the title of a movie or the name of a show or person
+func (m Media) DisplayTitle() string {
+	if m.Title != "" {
+		return m.Title
+	}
+	return m.Name
+}
+
+// Year is the release or first air year, or "" if unknown
+func (m Media) Year() string {
+	if m.ReleaseDate != "" {
+		return year(m.ReleaseDate)
+	}
+	return year(m.FirstAirDate)
+}
+
+// Trending fetches a page of what is trending for a media type (MediaAll,
+// MediaMovie, MediaTV or MediaPerson) over a day or a week
+func (c *Client) Trending(mediaType string, window TimeWindow, page int) (*Page[Media], error) {
+	var result Page[Media]
+	if err := c.get(fmt.Sprintf("/trending/%s/%s", mediaType, window), pageParams(page), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
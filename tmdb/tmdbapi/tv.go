@@ -0,0 +1,80 @@
+package tmdbapi
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// TVShow is a TV show as it appears in listings and search results
+type TVShow struct {
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`
+	OriginalName     string   `json:"original_name"`
+	OriginalLanguage string   `json:"original_language"`
+	Overview         string   `json:"overview"`
+	FirstAirDate     string   `json:"first_air_date"` // YYYY-MM-DD, may be empty
+	OriginCountry    []string `json:"origin_country"`
+	GenreIDs         []int    `json:"genre_ids,omitempty"`
+	Popularity       float64  `json:"popularity"`
+	VoteAverage      float64  `json:"vote_average"`
+	VoteCount        int      `json:"vote_count"`
+	PosterPath       string   `json:"poster_path"`
+}
+
+// Year is the year the show first aired, or "" if unknown
+func (s TVShow) Year() string {
+	return year(s.FirstAirDate)
+}
+
+// TVDetails is everything about one TV show, including its credits
+type TVDetails struct {
+	TVShow
+	Genres           []Genre   `json:"genres"`
+	Status           string    `json:"status"`
+	Tagline          string    `json:"tagline"`
+	LastAirDate      string    `json:"last_air_date"`
+	NumberOfSeasons  int       `json:"number_of_seasons"`
+	NumberOfEpisodes int       `json:"number_of_episodes"`
+	EpisodeRunTime   []int     `json:"episode_run_time"` // minutes
+	Homepage         string    `json:"homepage"`
+	CreatedBy        []Company `json:"created_by"`
+	Networks         []Company `json:"networks"`
+	Seasons          []Season  `json:"seasons"`
+	Credits          Credits   `json:"credits"`
+}
+
+// Company is a network, production company or show creator
+type Company struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Season is one season of a TV show
+type Season struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	SeasonNumber int    `json:"season_number"`
+	EpisodeCount int    `json:"episode_count"`
+	AirDate      string `json:"air_date"`
+}
+
+// SearchTV searches TV shows by name
+func (c *Client) SearchTV(query string, page int) (*Page[TVShow], error) {
+	params := pageParams(page)
+	params.Set("query", query)
+	var result Page[TVShow]
+	if err := c.get("/search/tv", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TV fetches the details and credits of a TV show
+func (c *Client) TV(id int) (*TVDetails, error) {
+	params := url.Values{"append_to_response": {"credits"}}
+	var result TVDetails
+	if err := c.get(fmt.Sprintf("/tv/%d", id), params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}